@@ -92,6 +92,8 @@ type helperT interface {
 
 const failureMessage = "assertion failed: "
 
+const warningMessage = "assertion warning: "
+
 // nolint: gocyclo
 func assert(
 	t TestingT,
@@ -124,7 +126,12 @@ func assert(
 			return true
 		}
 		msg := "error is not nil: "
-		t.Log(format.WithCustomMessage(failureMessage+msg+check.Error(), msgAndArgs...))
+		if multi, ok := format.MultiError(check); ok {
+			msg += multi
+		} else {
+			msg += check.Error()
+		}
+		t.Log(format.WithCustomMessage(failureMessage+msg, msgAndArgs...))
 
 	case cmp.Comparison:
 		success = runComparison(t, argSelector, check, msgAndArgs...)
@@ -217,6 +224,7 @@ func boolFailureMessage(expr ast.Expr) (string, error) {
 //   cmp.Comparison
 // Uses cmp.Result.Success() to check for success of failure.
 // The comparison is responsible for producing a helpful failure message.
+// A warning from cmp.ResultWarning is logged, but is not a failure.
 // http://gotest.tools/assert/cmp provides many common comparisons.
 //   error
 // A nil value is considered success.
@@ -241,6 +249,8 @@ func Check(t TestingT, comparison BoolOrComparison, msgAndArgs ...interface{}) b
 }
 
 // NilError fails the test immediately if err is not nil.
+// If err wraps multiple errors, for example an error from errors.Join, each
+// error is included in the failure message on a separate line.
 // This is equivalent to Assert(t, err)
 func NilError(t TestingT, err error, msgAndArgs ...interface{}) {
 	if ht, ok := t.(helperT); ok {
@@ -255,11 +265,23 @@ func NilError(t TestingT, err error, msgAndArgs ...interface{}) {
 // If the comparison fails Equal will use the variable names for x and y as part
 // of the failure message to identify the actual and expected values.
 //
-// If either x or y are a multi-line string the failure message will include a
-// unified diff of the two values. If the values only differ by whitespace
+// If either x or y are a multi-line string, or a string longer than 80
+// characters, the failure message will include a unified diff of the two
+// values. If the values only differ by whitespace
 // the unified diff will be augmented by replacing whitespace characters with
 // visible characters to identify the whitespace difference.
 //
+// If either x or y is an error or a fmt.Stringer, and both values print the
+// same message, the failure message also includes both values formatted with
+// %#v, because values which print the same message may not be equal. The
+// address of a pointer is included, so that two different errors with the
+// same fields can be told apart.
+//
+// Values of types which can not be compared with ==, like slices, maps, and
+// functions, fail with a message which suggests using DeepEqual instead. If
+// the uncomparable value is a field or element of x or y, the message includes
+// its path and type.
+//
 // This is equivalent to Assert(t, cmp.Equal(x, y)).
 func Equal(t TestingT, x, y interface{}, msgAndArgs ...interface{}) {
 	if ht, ok := t.(helperT); ok {
@@ -303,6 +325,7 @@ func ErrorContains(t TestingT, err error, substring string, msgAndArgs ...interf
 }
 
 // ErrorType fails the test if err is nil, or err is not the expected type.
+// Errors wrapped by err are also checked, see cmp.ErrorType.
 // Equivalent to Assert(t, cmp.ErrorType(err, expected)).
 //
 // Expected can be one of:
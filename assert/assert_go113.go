@@ -0,0 +1,17 @@
+//go:build go1.13
+// +build go1.13
+
+package assert
+
+import "gotest.tools/v3/assert/cmp"
+
+// ErrorIs fails the test if err is nil, or the error does not match expected
+// when compared using errors.Is. See https://golang.org/pkg/errors/#Is for
+// accepted argument values.
+// Equivalent to Assert(t, cmp.ErrorIs(err, expected)).
+func ErrorIs(t TestingT, err error, expected error, msgAndArgs ...interface{}) {
+	if ht, ok := t.(helperT); ok {
+		ht.Helper()
+	}
+	assert(t, t.FailNow, argsAfterT, cmp.ErrorIs(err, expected), msgAndArgs...)
+}
@@ -0,0 +1,29 @@
+//go:build go1.13
+// +build go1.13
+
+package assert
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestErrorIs(t *testing.T) {
+	t.Run("nil error", func(t *testing.T) {
+		fakeT := &fakeTestingT{}
+
+		var err error
+		ErrorIs(fakeT, err, os.ErrNotExist)
+		expected := `assertion failed: expected error "file does not exist" ` +
+			`(*errors.errorString), got nil`
+		expectFailNowed(t, fakeT, expected)
+	})
+	t.Run("wrapped error", func(t *testing.T) {
+		fakeT := &fakeTestingT{}
+
+		err := fmt.Errorf("open config: %w", os.ErrNotExist)
+		ErrorIs(fakeT, err, os.ErrNotExist)
+		expectSuccess(t, fakeT)
+	})
+}
@@ -3,6 +3,7 @@ package assert
 import (
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	gocmp "github.com/google/go-cmp/cmp"
@@ -143,6 +144,24 @@ func TestNilErrorFailure(t *testing.T) {
 	expectFailNowed(t, fakeT, "assertion failed: error is not nil: this is the error")
 }
 
+type multiError []error
+
+func (e multiError) Error() string {
+	return "first\nsecond"
+}
+
+func (e multiError) Unwrap() []error {
+	return e
+}
+
+func TestNilErrorFailureWithMultipleErrors(t *testing.T) {
+	fakeT := &fakeTestingT{}
+
+	NilError(fakeT, multiError{fmt.Errorf("first"), fmt.Errorf("second")})
+	expectFailNowed(t, fakeT,
+		"assertion failed: error is not nil: 2 errors:\n  - first\n  - second")
+}
+
 func TestCheckFailure(t *testing.T) {
 	fakeT := &fakeTestingT{}
 
@@ -361,3 +380,97 @@ func TestErrorTypeFailure(t *testing.T) {
 		expectFailNowed(t, fakeT, expected)
 	})
 }
+
+func TestNotComparison(t *testing.T) {
+	t.Run("check failure", func(t *testing.T) {
+		fakeT := &fakeTestingT{}
+
+		Check(fakeT, cmp.Not(cmp.Equal(1, 1)))
+		expectFailed(t, fakeT,
+			"assertion failed: expected comparison cmp.Equal to fail, but it succeeded")
+	})
+	t.Run("assert success", func(t *testing.T) {
+		fakeT := &fakeTestingT{}
+
+		Assert(fakeT, cmp.Not(cmp.Equal(1, 2)))
+		expectSuccess(t, fakeT)
+	})
+}
+
+func TestCheckWrappedComparisonArgs(t *testing.T) {
+	a, b := 1, 2
+	msg := "record %d"
+
+	t.Run("annotated", func(t *testing.T) {
+		fakeT := &fakeTestingT{}
+
+		Check(fakeT, cmp.Annotated(cmp.Equal(a, b), msg, 3))
+		expectFailed(t, fakeT, "assertion failed: 1 (int) != 2 (int): record 3")
+	})
+
+	t.Run("annotated diff", func(t *testing.T) {
+		fakeT := &fakeTestingT{}
+
+		x, y := []int{1}, []int{2}
+		Check(fakeT, cmp.Annotated(cmp.DeepEqual(x, y), msg, 1))
+		if !fakeT.failed || len(fakeT.msgs) != 1 {
+			t.Fatalf("expected failure, got %q", fakeT.msgs)
+		}
+		if !strings.Contains(fakeT.msgs[0], "--- ←\n+++ →\n") {
+			t.Errorf("expected diff header without args, got %q", fakeT.msgs[0])
+		}
+	})
+
+	t.Run("timed variable", func(t *testing.T) {
+		fakeT := &fakeTestingT{}
+
+		check := cmp.Equal(a, b)
+		Check(fakeT, cmp.Timed(check))
+		if !fakeT.failed || len(fakeT.msgs) != 1 {
+			t.Fatalf("expected failure, got %q", fakeT.msgs)
+		}
+		expected := "assertion failed: 1 (int) != 2 (int) (comparison took "
+		if !strings.HasPrefix(fakeT.msgs[0], expected) {
+			t.Errorf("expected message to start with %q, got %q", expected, fakeT.msgs[0])
+		}
+	})
+}
+
+func TestAssertWithWarning(t *testing.T) {
+	warning := func() cmp.Result {
+		return cmp.ResultWarning("deprecated field is set")
+	}
+
+	t.Run("check", func(t *testing.T) {
+		fakeT := &fakeTestingT{}
+
+		if !Check(fakeT, warning, "id=%d", 3) {
+			t.Error("expected check to return true for a warning")
+		}
+		expectSuccess(t, fakeT)
+		expected := "assertion warning: deprecated field is set: id=3"
+		if len(fakeT.msgs) != 1 || fakeT.msgs[0] != expected {
+			t.Errorf("expected message %q, got %q", expected, fakeT.msgs)
+		}
+	})
+
+	t.Run("assert", func(t *testing.T) {
+		fakeT := &fakeTestingT{}
+
+		Assert(fakeT, warning)
+		expectSuccess(t, fakeT)
+		if len(fakeT.msgs) != 1 {
+			t.Errorf("expected warning to be logged, got %q", fakeT.msgs)
+		}
+	})
+
+	t.Run("success is not logged", func(t *testing.T) {
+		fakeT := &fakeTestingT{}
+
+		Assert(fakeT, cmp.Equal(1, 1))
+		expectSuccess(t, fakeT)
+		if len(fakeT.msgs) != 0 {
+			t.Errorf("expected no messages, got %q", fakeT.msgs)
+		}
+	})
+}
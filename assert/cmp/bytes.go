@@ -0,0 +1,53 @@
+package cmp
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// bytesWindow is the number of bytes shown on each side of the first
+// difference in the failure message from BytesEqual.
+const bytesWindow = 8
+
+// BytesEqual succeeds if x and y contain the same bytes. Unlike DeepEqual, the
+// failure message does not include a diff, which is slow to build and hard to
+// read for binary data. Instead the message includes the lengths of x and y,
+// the index of the first byte which is different, and the bytes around that
+// index in hex.
+func BytesEqual(x, y []byte) Comparison {
+	return func() Result {
+		if bytes.Equal(x, y) {
+			return ResultSuccess
+		}
+		index := firstDifferentByte(x, y)
+		return ResultFailure(fmt.Sprintf(
+			"byte slices differ at index %d (x length %d, y length %d)\nx%s\ny%s",
+			index, len(x), len(y), formatBytesWindow(x, index), formatBytesWindow(y, index)))
+	}
+}
+
+func firstDifferentByte(x, y []byte) int {
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if x[i] != y[i] {
+			return i
+		}
+	}
+	if len(x) < len(y) {
+		return len(x)
+	}
+	return len(y)
+}
+
+func formatBytesWindow(seq []byte, index int) string {
+	start, end := index-bytesWindow, index+bytesWindow
+	if start < 0 {
+		start = 0
+	}
+	if end > len(seq) {
+		end = len(seq)
+	}
+	if start > end {
+		start = end
+	}
+	return fmt.Sprintf("[%d:%d]: % x", start, end, seq[start:end])
+}
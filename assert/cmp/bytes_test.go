@@ -0,0 +1,59 @@
+package cmp
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBytesEqual(t *testing.T) {
+	t.Run("equal", func(t *testing.T) {
+		assertSuccess(t, BytesEqual([]byte("abc"), []byte("abc"))())
+		assertSuccess(t, BytesEqual(nil, []byte{})())
+	})
+
+	t.Run("different byte", func(t *testing.T) {
+		x := bytes.Repeat([]byte{0xaa}, 32)
+		y := bytes.Repeat([]byte{0xaa}, 32)
+		y[20] = 0xbb
+		expected := "byte slices differ at index 20 (x length 32, y length 32)\n" +
+			"x[12:28]: aa aa aa aa aa aa aa aa aa aa aa aa aa aa aa aa\n" +
+			"y[12:28]: aa aa aa aa aa aa aa aa bb aa aa aa aa aa aa aa"
+		assertFailure(t, BytesEqual(x, y)(), expected)
+	})
+
+	t.Run("difference near the start", func(t *testing.T) {
+		expected := "byte slices differ at index 1 (x length 3, y length 3)\n" +
+			"x[0:3]: 01 02 03\n" +
+			"y[0:3]: 01 00 03"
+		assertFailure(t, BytesEqual([]byte{1, 2, 3}, []byte{1, 0, 3})(), expected)
+	})
+
+	t.Run("different lengths", func(t *testing.T) {
+		expected := "byte slices differ at index 2 (x length 2, y length 4)\n" +
+			"x[0:2]: 01 02\n" +
+			"y[0:4]: 01 02 03 04"
+		assertFailure(t, BytesEqual([]byte{1, 2}, []byte{1, 2, 3, 4})(), expected)
+	})
+
+	t.Run("nil and non-empty", func(t *testing.T) {
+		expected := "byte slices differ at index 0 (x length 1, y length 0)\n" +
+			"x[0:1]: ff\n" +
+			"y[0:0]: "
+		assertFailure(t, BytesEqual([]byte{0xff}, nil)(), expected)
+	})
+}
+
+func BenchmarkBytesEqual(b *testing.B) {
+	x := bytes.Repeat([]byte{0x01}, 16<<20)
+	y := bytes.Repeat([]byte{0x01}, 16<<20)
+	y[len(y)-1] = 0x02
+
+	b.SetBytes(int64(len(x)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if BytesEqual(x, y)().Success() {
+			b.Fatal("expected failure")
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package cmp
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// ReceivesWithin succeeds if a value is received from the channel ch before
+// timeout, or if ch is closed before timeout. ch may be any channel type which
+// can receive values.
+func ReceivesWithin(ch interface{}, timeout time.Duration) Comparison {
+	comparison, _ := ReceivesWithinAndReturn(ch, timeout)
+	return comparison
+}
+
+// ReceivesWithinAndReturn returns a Comparison which is the same as
+// ReceivesWithin, and a pointer to the received value. The value is set when
+// the Comparison is run, so that it can be inspected with other comparisons.
+// If the channel was closed the value is the zero value of the element type.
+//
+// Example:
+//   receives, value := cmp.ReceivesWithinAndReturn(events, time.Second)
+//   assert.Assert(t, receives)
+//   assert.Equal(t, (*value).(string), "started")
+func ReceivesWithinAndReturn(ch interface{}, timeout time.Duration) (Comparison, *interface{}) {
+	received := new(interface{})
+	return func() Result {
+		value := reflect.ValueOf(ch)
+		switch {
+		case !value.IsValid():
+			return ResultFailure("nil is not a channel")
+		case value.Kind() != reflect.Chan:
+			return ResultFailure(fmt.Sprintf("type %T is not a channel", ch))
+		case value.Type().ChanDir()&reflect.RecvDir == 0:
+			return ResultFailure(fmt.Sprintf("can not receive on send-only channel %T", ch))
+		}
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		chosen, recv, _ := reflect.Select([]reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: value},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)},
+		})
+		if chosen == 1 {
+			return ResultFailure(fmt.Sprintf(
+				"timed out after %s waiting to receive on %T", timeout, ch))
+		}
+		*received = recv.Interface()
+		return ResultSuccess
+	}, received
+}
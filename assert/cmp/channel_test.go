@@ -0,0 +1,54 @@
+package cmp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReceivesWithin(t *testing.T) {
+	t.Run("receives a value", func(t *testing.T) {
+		ch := make(chan int)
+		go func() { ch <- 3 }()
+
+		receives, value := ReceivesWithinAndReturn(ch, time.Second)
+		assertSuccess(t, receives())
+		if *value != 3 {
+			t.Errorf("expected received value 3, got %v", *value)
+		}
+	})
+
+	t.Run("channel is closed", func(t *testing.T) {
+		ch := make(chan string)
+		close(ch)
+
+		receives, value := ReceivesWithinAndReturn(ch, time.Second)
+		assertSuccess(t, receives())
+		if *value != "" {
+			t.Errorf("expected zero value, got %v", *value)
+		}
+	})
+
+	t.Run("receive-only channel", func(t *testing.T) {
+		ch := make(chan struct{}, 1)
+		ch <- struct{}{}
+		var recvOnly <-chan struct{} = ch
+		assertSuccess(t, ReceivesWithin(recvOnly, time.Second)())
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ch := make(chan int)
+		result := ReceivesWithin(ch, 10*time.Millisecond)()
+		assertFailure(t, result, "timed out after 10ms waiting to receive on chan int")
+	})
+
+	t.Run("send-only channel", func(t *testing.T) {
+		var ch chan<- int = make(chan int)
+		result := ReceivesWithin(ch, time.Second)()
+		assertFailure(t, result, "can not receive on send-only channel chan<- int")
+	})
+
+	t.Run("not a channel", func(t *testing.T) {
+		assertFailure(t, ReceivesWithin([]int{1}, time.Second)(), "type []int is not a channel")
+		assertFailure(t, ReceivesWithin(nil, time.Second)(), "nil is not a channel")
+	})
+}
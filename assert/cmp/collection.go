@@ -0,0 +1,583 @@
+package cmp
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ElementsMatch succeeds if x and y contain the same elements, ignoring the
+// order of the elements. x and y may be a slice or an array. Elements are
+// compared using reflect.DeepEqual(), and each element in x must be matched by
+// a different element in y, so the number of duplicates must also be the same.
+//
+// The failure message lists the elements which are only in x, and only in y,
+// sorted by their formatted value. When an element is in both x and y, but a
+// different number of times, the number of times it appears in each is shown.
+func ElementsMatch(x, y interface{}) Comparison {
+	return func() Result {
+		xValue, err := sequenceValue(x)
+		if err != nil {
+			return ResultFailure(err.Error())
+		}
+		yValue, err := sequenceValue(y)
+		if err != nil {
+			return ResultFailure(err.Error())
+		}
+
+		counts, hashable := countElements(xValue, yValue)
+		var onlyX, onlyY []elementCount
+		for _, count := range counts {
+			switch {
+			case count.x > count.y:
+				onlyX = append(onlyX, count)
+			case count.y > count.x:
+				onlyY = append(onlyY, count)
+			}
+		}
+		if len(onlyX) == 0 && len(onlyY) == 0 {
+			return ResultSuccess
+		}
+
+		buf := new(bytes.Buffer)
+		fmt.Fprintf(buf, "%v and %v do not contain the same elements", x, y)
+		writeElementCounts(buf, "only in x:", onlyX)
+		writeElementCounts(buf, "only in y:", onlyY)
+		if !hashable {
+			buf.WriteString("\n(elements could not be used as map keys, " +
+				"so they were compared in pairs using reflect.DeepEqual)")
+		}
+		return ResultFailure(buf.String())
+	}
+}
+
+// elementCount is the number of times an element appears in x and in y.
+type elementCount struct {
+	value     interface{}
+	formatted string
+	x, y      int
+}
+
+// countElements returns the number of times each distinct element appears in
+// x and y, sorted by the formatted value of the element. Elements are counted
+// using a map when every element can be used as a map key without changing the
+// result of reflect.DeepEqual. Otherwise elements are compared in pairs using
+// reflect.DeepEqual, and hashable is false.
+func countElements(x, y reflect.Value) (counts []elementCount, hashable bool) {
+	hashable = isHashableSequence(x) && isHashableSequence(y)
+	index := make(map[interface{}]int)
+	add := func(value interface{}, inX bool) {
+		i, ok := -1, false
+		if hashable {
+			i, ok = index[value]
+		} else {
+			for j := range counts {
+				if reflect.DeepEqual(counts[j].value, value) {
+					i, ok = j, true
+					break
+				}
+			}
+		}
+		if !ok {
+			i = len(counts)
+			counts = append(counts, elementCount{value: value, formatted: fmt.Sprintf("%#v", value)})
+			if hashable {
+				index[value] = i
+			}
+		}
+		if inX {
+			counts[i].x++
+		} else {
+			counts[i].y++
+		}
+	}
+	for i := 0; i < x.Len(); i++ {
+		add(x.Index(i).Interface(), true)
+	}
+	for i := 0; i < y.Len(); i++ {
+		add(y.Index(i).Interface(), false)
+	}
+	sort.SliceStable(counts, func(i, j int) bool {
+		return counts[i].formatted < counts[j].formatted
+	})
+	return counts, hashable
+}
+
+// isHashableSequence returns true if every element of seq can be used as a map
+// key, and == gives the same result as reflect.DeepEqual for the elements.
+func isHashableSequence(seq reflect.Value) bool {
+	for i := 0; i < seq.Len(); i++ {
+		if !isHashableValue(seq.Index(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+func isHashableValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr, reflect.Float32, reflect.Float64,
+		reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Interface:
+		return value.IsNil() || isHashableValue(value.Elem())
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if !isHashableValue(value.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if !isHashableValue(value.Field(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	// Pointers are compared by address with ==, but by the value they point to
+	// with reflect.DeepEqual, so they are not considered hashable.
+	return false
+}
+
+func writeElementCounts(buf *bytes.Buffer, title string, counts []elementCount) {
+	if len(counts) == 0 {
+		return
+	}
+	buf.WriteString("\n" + title)
+	for _, count := range counts {
+		fmt.Fprintf(buf, "\n  %s", count.formatted)
+		if count.x > 1 || count.y > 1 || count.x > 0 && count.y > 0 {
+			fmt.Fprintf(buf, " (%d in x, %d in y)", count.x, count.y)
+		}
+	}
+}
+
+// sequenceValue returns the reflect.Value of seq, or an error if seq is not a
+// slice or an array.
+func sequenceValue(seq interface{}) (reflect.Value, error) {
+	value := reflect.ValueOf(seq)
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		return value, nil
+	}
+	return value, fmt.Errorf("type %T is not a slice or array", seq)
+}
+
+// diffElements returns the elements of x which do not have a matching element
+// in y, and the elements of y which do not have a matching element in x.
+func diffElements(x, y reflect.Value) (onlyX, onlyY []interface{}) {
+	matched := make([]bool, y.Len())
+	for i := 0; i < x.Len(); i++ {
+		item := x.Index(i).Interface()
+		found := false
+		for j := 0; j < y.Len(); j++ {
+			if matched[j] || !reflect.DeepEqual(item, y.Index(j).Interface()) {
+				continue
+			}
+			matched[j], found = true, true
+			break
+		}
+		if !found {
+			onlyX = append(onlyX, item)
+		}
+	}
+	for j, ok := range matched {
+		if !ok {
+			onlyY = append(onlyY, y.Index(j).Interface())
+		}
+	}
+	return onlyX, onlyY
+}
+
+// Each succeeds if the comparison returned by fn succeeds for every element of
+// seq. fn is called with the index and value of each element. seq may be a
+// slice or an array. If any of the comparisons fail, the failure message
+// includes the index and failure message of every element which failed. If
+// none of the comparisons fail, Each returns the first warning from
+// cmp.ResultWarning, prefixed with the index of the element, if there was one.
+// A panic from fn, or from the Comparison it returns, is a failure of that
+// element.
+//
+// Example:
+//   assert.Check(t, cmp.Each(ids, func(_ int, id interface{}) cmp.Comparison {
+//       return cmp.NotEmpty(id)
+//   }))
+func Each(seq interface{}, fn func(i int, v interface{}) Comparison) Comparison {
+	return func() Result {
+		value, err := sequenceValue(seq)
+		if err != nil {
+			return ResultFailure(err.Error())
+		}
+		buf := new(bytes.Buffer)
+		var failed int
+		var warning Result
+		for i := 0; i < value.Len(); i++ {
+			var result Result
+			recovered, panicked := recoverPanic(func() {
+				result = fn(i, value.Index(i).Interface())()
+			})
+			if panicked {
+				result = ResultFailure("panicked: " + panicMessage(recovered))
+			}
+			if warning == nil && isWarning(result) {
+				warning = ResultWarning(fmt.Sprintf("[%d]: %s", i, failureMessage(result, nil)))
+			}
+			if result.Success() {
+				continue
+			}
+			failed++
+			fmt.Fprintf(buf, "\n  [%d]:%s", i, indentMessage(failureMessage(result, nil)))
+		}
+		switch {
+		case failed == 0 && warning != nil:
+			return warning
+		case failed == 0:
+			return ResultSuccess
+		}
+		return ResultFailure(fmt.Sprintf(
+			"%d of %d elements failed:%s", failed, value.Len(), buf.String()))
+	}
+}
+
+// ContainsAll succeeds if every one of items is in collection. See Contains for
+// the supported types of collection, and how items are compared.
+func ContainsAll(collection interface{}, items ...interface{}) Comparison {
+	return func() Result {
+		var missing []string
+		for _, item := range items {
+			found, err := containsItem(collection, item)
+			if err != nil {
+				return ResultFailure(err.Error())
+			}
+			if !found {
+				missing = append(missing, fmt.Sprintf("%#v", item))
+			}
+		}
+		if len(missing) == 0 {
+			return ResultSuccess
+		}
+		return ResultFailure(fmt.Sprintf(
+			"%v is missing: %s", collection, strings.Join(missing, ", ")))
+	}
+}
+
+// ContainsAny succeeds if at least one of items is in collection. See Contains
+// for the supported types of collection, and how items are compared.
+//
+// ContainsAny fails if no items are given.
+func ContainsAny(collection interface{}, items ...interface{}) Comparison {
+	return func() Result {
+		if len(items) == 0 {
+			return ResultFailure("no items to find in collection")
+		}
+		formatted := make([]string, 0, len(items))
+		for _, item := range items {
+			found, err := containsItem(collection, item)
+			switch {
+			case err != nil:
+				return ResultFailure(err.Error())
+			case found:
+				return ResultSuccess
+			}
+			formatted = append(formatted, fmt.Sprintf("%#v", item))
+		}
+		return ResultFailure(fmt.Sprintf(
+			"%v does not contain any of: %s", collection, strings.Join(formatted, ", ")))
+	}
+}
+
+// ContainsOrdered succeeds if items appear in collection in the same order,
+// but not necessarily next to each other. Collection may be a string, slice, or
+// array. If collection is a string, each item must be a string, and is found
+// using strings.Index() starting from the end of the previous match. Elements
+// of a slice or array are compared to items using reflect.DeepEqual().
+//
+// The failure message includes the first item which could not be found after
+// the position of the previous item.
+//
+// Example:
+//   assert.Check(t, cmp.ContainsOrdered(logOutput, "starting", "ready", "stopped"))
+func ContainsOrdered(collection interface{}, items ...interface{}) Comparison {
+	return func() Result {
+		colValue := reflect.ValueOf(collection)
+		if !colValue.IsValid() {
+			return ResultFailure("nil does not contain items")
+		}
+
+		switch colValue.Kind() {
+		case reflect.String:
+			return containsOrderedSubstrings(colValue.String(), items)
+		case reflect.Slice, reflect.Array:
+		default:
+			return ResultFailure(fmt.Sprintf("type %T does not support ordered items", collection))
+		}
+
+		start := 0
+		for n, item := range items {
+			index := -1
+			for i := start; i < colValue.Len(); i++ {
+				if reflect.DeepEqual(colValue.Index(i).Interface(), item) {
+					index = i
+					break
+				}
+			}
+			if index >= 0 {
+				start = index + 1
+				continue
+			}
+			if n == 0 {
+				return ResultFailure(fmt.Sprintf("%v does not contain %#v", collection, item))
+			}
+			return ResultFailure(fmt.Sprintf("%v does not contain %#v after %#v at index %d",
+				collection, item, items[n-1], start-1))
+		}
+		return ResultSuccess
+	}
+}
+
+func containsOrderedSubstrings(value string, items []interface{}) Result {
+	start, prev := 0, -1
+	for n, item := range items {
+		substr, ok := item.(string)
+		if !ok {
+			return ResultFailure("string may only contain strings")
+		}
+		index := strings.Index(value[start:], substr)
+		if index >= 0 {
+			prev = start + index
+			start = prev + len(substr)
+			continue
+		}
+		if n == 0 {
+			return ResultFailure(fmt.Sprintf("string %q does not contain %q", value, substr))
+		}
+		return ResultFailure(fmt.Sprintf("string %q does not contain %q after %q at offset %d",
+			value, substr, items[n-1], prev))
+	}
+	return ResultSuccess
+}
+
+// ContainsCount succeeds if item appears exactly n times in collection.
+// Collection may be a string, slice, or array. If collection is a string, item
+// must also be a string, and the number of non-overlapping instances of item
+// is counted using strings.Count(). An empty item string is not supported,
+// because it can not be counted in a meaningful way. Elements of a slice or
+// array are compared to item using reflect.DeepEqual().
+//
+// ContainsCount does not support maps, because each key is always unique.
+func ContainsCount(collection interface{}, item interface{}, n int) Comparison {
+	return func() Result {
+		colValue := reflect.ValueOf(collection)
+		if !colValue.IsValid() {
+			return ResultFailure("nil does not contain items")
+		}
+
+		var count int
+		switch colValue.Kind() {
+		case reflect.String:
+			itemValue := reflect.ValueOf(item)
+			if itemValue.Kind() != reflect.String {
+				return ResultFailure("string may only contain strings")
+			}
+			if itemValue.Len() == 0 {
+				return ResultFailure("can not count an empty string")
+			}
+			count = strings.Count(colValue.String(), itemValue.String())
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < colValue.Len(); i++ {
+				if reflect.DeepEqual(colValue.Index(i).Interface(), item) {
+					count++
+				}
+			}
+		case reflect.Map:
+			return ResultFailure("ContainsCount does not support maps")
+		default:
+			return ResultFailure(fmt.Sprintf("type %T does not contain items", collection))
+		}
+		return toResult(count == n, fmt.Sprintf(
+			"expected %v to contain %#v %d times, found %d", collection, item, n, count))
+	}
+}
+
+// MapSubset succeeds if every key in subset is also a key in superset, and the
+// values for those keys are equal. Values are compared using
+// reflect.DeepEqual(). superset and subset must be maps of the same type.
+func MapSubset(superset, subset interface{}) Comparison {
+	return func() Result {
+		superValue := reflect.ValueOf(superset)
+		subValue := reflect.ValueOf(subset)
+		if superValue.Kind() != reflect.Map || subValue.Kind() != reflect.Map {
+			return ResultFailure(fmt.Sprintf(
+				"MapSubset requires two maps, got %T and %T", superset, subset))
+		}
+		if superValue.Type() != subValue.Type() {
+			return ResultFailure(fmt.Sprintf(
+				"can not compare %s to %s, maps must be the same type",
+				superValue.Type(), subValue.Type()))
+		}
+
+		var problems []string
+		for _, key := range sortedMapKeys(subValue) {
+			expected := subValue.MapIndex(key).Interface()
+			actual := superValue.MapIndex(key)
+			switch {
+			case !actual.IsValid():
+				problems = append(problems, fmt.Sprintf("missing key %#v", key.Interface()))
+			case !reflect.DeepEqual(actual.Interface(), expected):
+				problems = append(problems, fmt.Sprintf("key %#v: expected %#v, got %#v",
+					key.Interface(), expected, actual.Interface()))
+			}
+		}
+		if len(problems) == 0 {
+			return ResultSuccess
+		}
+		return ResultFailure(fmt.Sprintf("%v does not contain all of %v\n%s",
+			superset, subset, strings.Join(problems, "\n")))
+	}
+}
+
+// maxListedValues is the number of map values included in the failure message
+// from ContainsValue.
+const maxListedValues = 10
+
+// ContainsValue succeeds if value is one of the values in the map m. Values are
+// compared using reflect.DeepEqual(). Use Contains to check the keys of a map.
+//
+// ContainsValue fails if m is not a map, or if value can not be assigned to the
+// value type of the map. The failure message lists the values in the map, up
+// to a maximum of 10 values.
+func ContainsValue(m interface{}, value interface{}) Comparison {
+	return func() Result {
+		mapValue := reflect.ValueOf(m)
+		if mapValue.Kind() != reflect.Map {
+			return ResultFailure(fmt.Sprintf("type %T is not a map", m))
+		}
+		elemType := mapValue.Type().Elem()
+		switch typ := reflect.TypeOf(value); {
+		case typ == nil && !isNillable(elemType):
+			return ResultFailure(fmt.Sprintf("%v can not contain a nil value", mapValue.Type()))
+		case typ != nil && !typ.AssignableTo(elemType):
+			return ResultFailure(fmt.Sprintf(
+				"%v can not contain a %v value", mapValue.Type(), typ))
+		}
+
+		// An untyped nil matches the nil value of the value type of the map.
+		target := value
+		if value == nil {
+			target = reflect.Zero(elemType).Interface()
+		}
+
+		keys := sortedMapKeys(mapValue)
+		values := make([]string, 0, maxListedValues)
+		for _, key := range keys {
+			item := mapValue.MapIndex(key).Interface()
+			if reflect.DeepEqual(item, target) {
+				return ResultSuccess
+			}
+			if len(values) < maxListedValues {
+				values = append(values, fmt.Sprintf("%#v", item))
+			}
+		}
+		listed := strings.Join(values, ", ")
+		if len(keys) > maxListedValues {
+			listed += fmt.Sprintf(", ... (%d more)", len(keys)-maxListedValues)
+		}
+		return ResultFailure(fmt.Sprintf(
+			"map does not contain value %#v, values are: %s", value, listed))
+	}
+}
+
+// sortedMapKeys returns the keys of the map value sorted by their formatted
+// value, so that failure messages are stable.
+func sortedMapKeys(value reflect.Value) []reflect.Value {
+	keys := value.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprintf("%#v", keys[i].Interface()) < fmt.Sprintf("%#v", keys[j].Interface())
+	})
+	return keys
+}
+
+// Sorted succeeds if seq is sorted according to less, using the same rules as
+// sort.SliceIsSorted. seq must be a slice or an array, and less reports whether
+// the element at index i should sort before the element at index j.
+func Sorted(seq interface{}, less func(i, j int) bool) Comparison {
+	return func() (result Result) {
+		defer func() {
+			if e := recover(); e != nil {
+				result = ResultFailure(fmt.Sprintf("failed to check order of %T: %v", seq, e))
+			}
+		}()
+		value, err := sequenceValue(seq)
+		if err != nil {
+			return ResultFailure(err.Error())
+		}
+		for i := 1; i < value.Len(); i++ {
+			if less(i, i-1) {
+				return ResultFailure(fmt.Sprintf(
+					"%v is not sorted: index %d (%v) should not be before index %d (%v)",
+					seq, i-1, value.Index(i-1).Interface(), i, value.Index(i).Interface()))
+			}
+		}
+		return ResultSuccess
+	}
+}
+
+// SortedStrings succeeds if seq is sorted in increasing order.
+func SortedStrings(seq []string) Comparison {
+	return Sorted(seq, func(i, j int) bool { return seq[i] < seq[j] })
+}
+
+// SortedInts succeeds if seq is sorted in increasing order.
+func SortedInts(seq []int) Comparison {
+	return Sorted(seq, func(i, j int) bool { return seq[i] < seq[j] })
+}
+
+// MapValuesEqual succeeds if x and y both contain each of the keys, and the
+// values for each key are equal. Values are compared using reflect.DeepEqual().
+// Keys which are not listed are ignored. x and y must be maps of the same type.
+func MapValuesEqual(x, y interface{}, keys ...interface{}) Comparison {
+	return func() Result {
+		xValue, yValue := reflect.ValueOf(x), reflect.ValueOf(y)
+		if xValue.Kind() != reflect.Map || yValue.Kind() != reflect.Map {
+			return ResultFailure(fmt.Sprintf(
+				"MapValuesEqual requires two maps, got %T and %T", x, y))
+		}
+		if xValue.Type() != yValue.Type() {
+			return ResultFailure(fmt.Sprintf(
+				"can not compare %s to %s, maps must be the same type",
+				xValue.Type(), yValue.Type()))
+		}
+
+		var problems []string
+		for _, key := range keys {
+			keyValue := reflect.ValueOf(key)
+			if !keyValue.IsValid() || keyValue.Type() != xValue.Type().Key() {
+				return ResultFailure(fmt.Sprintf(
+					"%s can not contain a %T key", xValue.Type(), key))
+			}
+			xItem, yItem := xValue.MapIndex(keyValue), yValue.MapIndex(keyValue)
+			switch {
+			case !xItem.IsValid() && !yItem.IsValid():
+				problems = append(problems, fmt.Sprintf("key %#v: missing in x and y", key))
+			case !xItem.IsValid():
+				problems = append(problems, fmt.Sprintf("key %#v: missing in x", key))
+			case !yItem.IsValid():
+				problems = append(problems, fmt.Sprintf("key %#v: missing in y", key))
+			case !reflect.DeepEqual(xItem.Interface(), yItem.Interface()):
+				problems = append(problems, fmt.Sprintf("key %#v: %#v (x) != %#v (y)",
+					key, xItem.Interface(), yItem.Interface()))
+			}
+		}
+		if len(problems) == 0 {
+			return ResultSuccess
+		}
+		return ResultFailure("map values are not equal\n" + strings.Join(problems, "\n"))
+	}
+}
@@ -0,0 +1,731 @@
+package cmp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestElementsMatch(t *testing.T) {
+	var testcases = []struct {
+		name        string
+		x, y        interface{}
+		expected    bool
+		expectedMsg string
+	}{
+		{
+			name:     "same order",
+			x:        []int{1, 2, 3},
+			y:        []int{1, 2, 3},
+			expected: true,
+		},
+		{
+			name:     "different order",
+			x:        []string{"a", "b", "c"},
+			y:        [3]string{"c", "a", "b"},
+			expected: true,
+		},
+		{
+			name:     "both empty",
+			x:        []int{},
+			y:        []int(nil),
+			expected: true,
+		},
+		{
+			name:     "duplicates",
+			x:        []int{1, 2, 2},
+			y:        []int{2, 1, 2},
+			expected: true,
+		},
+		{
+			name: "different number of duplicates",
+			x:    []int{1, 2, 2},
+			y:    []int{2, 1, 1},
+			expectedMsg: "[1 2 2] and [2 1 1] do not contain the same elements\n" +
+				"only in x:\n  2 (2 in x, 1 in y)\n" +
+				"only in y:\n  1 (1 in x, 2 in y)",
+		},
+		{
+			name: "only in y",
+			x:    []int{1},
+			y:    []int{1, 3},
+			expectedMsg: "[1] and [1 3] do not contain the same elements\n" +
+				"only in y:\n  3",
+		},
+		{
+			name: "sorted output",
+			x:    []string{"d", "b", "a", "a"},
+			y:    []string{"c", "e", "a", "e"},
+			expectedMsg: "[d b a a] and [c e a e] do not contain the same elements\n" +
+				"only in x:\n  \"a\" (2 in x, 1 in y)\n  \"b\"\n  \"d\"\n" +
+				"only in y:\n  \"c\"\n  \"e\" (0 in x, 2 in y)",
+		},
+		{
+			name:     "unhashable elements",
+			x:        [][]int{{1}, {2}},
+			y:        [][]int{{2}, {1}},
+			expected: true,
+		},
+		{
+			name: "unhashable elements with differences",
+			x:    []interface{}{[]int{1}, 2, []int{1}},
+			y:    []interface{}{[]int{1}, 3},
+			expectedMsg: "[[1] 2 [1]] and [[1] 3] do not contain the same elements\n" +
+				"only in x:\n  2\n  []int{1} (2 in x, 1 in y)\n" +
+				"only in y:\n  3\n" +
+				"(elements could not be used as map keys, so they were compared in pairs using reflect.DeepEqual)",
+		},
+		{
+			name:     "pointers are compared by value",
+			x:        []*int{intPtr(1), intPtr(2)},
+			y:        []*int{intPtr(2), intPtr(1)},
+			expected: true,
+		},
+		{
+			name:     "hashable structs",
+			x:        []server{{"a", 1}, {"b", 2}},
+			y:        [2]server{{"b", 2}, {"a", 1}},
+			expected: true,
+		},
+		{
+			name:        "not a slice",
+			x:           map[string]int{},
+			y:           []int{},
+			expectedMsg: "type map[string]int is not a slice or array",
+		},
+		{
+			name:        "nil",
+			x:           []int{},
+			y:           nil,
+			expectedMsg: "type <nil> is not a slice or array",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result := ElementsMatch(testcase.x, testcase.y)()
+			if testcase.expected {
+				assertSuccess(t, result)
+			} else {
+				assertFailure(t, result, testcase.expectedMsg)
+			}
+		})
+	}
+}
+
+func TestEach(t *testing.T) {
+	notEmpty := func(_ int, v interface{}) Comparison {
+		return NotEmpty(v)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		assertSuccess(t, Each([]string{"a", "b"}, notEmpty)())
+		assertSuccess(t, Each([0]string{}, notEmpty)())
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		result := Each([]string{"a", "", "c", ""}, notEmpty)()
+		assertFailure(t, result, "2 of 4 elements failed:\n"+
+			"  [1]: expected  (string) to not be empty\n"+
+			"  [3]: expected  (string) to not be empty")
+	})
+
+	t.Run("with index", func(t *testing.T) {
+		result := Each([3]int{0, 5, 12}, func(i int, v interface{}) Comparison {
+			return WithLabel(fmt.Sprintf("latency %d", i), Between(v, 0, 10))
+		})()
+		assertFailure(t, result,
+			"1 of 3 elements failed:\n  [2]: latency 2: expected 12 to be between 0 and 10")
+	})
+
+	t.Run("multi-line message", func(t *testing.T) {
+		result := Each([]int{1}, func(_ int, v interface{}) Comparison {
+			return func() Result { return ResultFailure("first\nsecond\n") }
+		})()
+		assertFailure(t, result, "1 of 1 elements failed:\n  [0]:\n    first\n    second")
+	})
+
+	t.Run("panics", func(t *testing.T) {
+		result := Each([]interface{}{"a", 2, "c"}, func(_ int, v interface{}) Comparison {
+			s := v.(string)
+			return func() Result {
+				if s == "c" {
+					panic("comparison of c")
+				}
+				return ResultSuccess
+			}
+		})()
+		assertFailure(t, result, "2 of 3 elements failed:\n"+
+			"  [1]: panicked: interface conversion: interface {} is int, not string\n"+
+			"  [2]: panicked: comparison of c")
+	})
+
+	t.Run("not a sequence", func(t *testing.T) {
+		assertFailure(t, Each("abc", notEmpty)(), "type string is not a slice or array")
+	})
+}
+
+func TestContainsAll(t *testing.T) {
+	var testcases = []struct {
+		name        string
+		collection  interface{}
+		items       []interface{}
+		expected    bool
+		expectedMsg string
+	}{
+		{
+			name:       "slice contains all",
+			collection: []string{"a", "b", "c"},
+			items:      []interface{}{"c", "a"},
+			expected:   true,
+		},
+		{
+			name:       "no items",
+			collection: []string{"a"},
+			expected:   true,
+		},
+		{
+			name:        "slice missing items",
+			collection:  []string{"a", "c"},
+			items:       []interface{}{"a", "b", "c", "d"},
+			expectedMsg: `[a c] is missing: "b", "d"`,
+		},
+		{
+			name:       "string contains all",
+			collection: "the quick fox",
+			items:      []interface{}{"quick", "fox"},
+			expected:   true,
+		},
+		{
+			name:        "map missing key",
+			collection:  map[int]bool{1: true},
+			items:       []interface{}{1, 2},
+			expectedMsg: "map[1:true] is missing: 2",
+		},
+		{
+			name:       "nil element",
+			collection: []*int{intPtr(1), nil},
+			items:      []interface{}{nil},
+			expected:   true,
+		},
+		{
+			name:        "missing nil element",
+			collection:  []error{fmt.Errorf("one")},
+			items:       []interface{}{nil},
+			expectedMsg: "[one] is missing: <nil>",
+		},
+		{
+			name:        "nil item in slice of int",
+			collection:  []int{1},
+			items:       []interface{}{1, nil},
+			expectedMsg: "[]int can not contain a nil value",
+		},
+		{
+			name:       "nil map key",
+			collection: map[interface{}]int{nil: 1},
+			items:      []interface{}{nil},
+			expected:   true,
+		},
+		{
+			name:        "nil item in map",
+			collection:  map[string]int{},
+			items:       []interface{}{nil},
+			expectedMsg: "map[string]int can not contain a nil key",
+		},
+		{
+			name:        "nil item in string",
+			collection:  "abc",
+			items:       []interface{}{nil},
+			expectedMsg: "string may only contain strings",
+		},
+		{
+			name:        "invalid item type",
+			collection:  map[int]bool{1: true},
+			items:       []interface{}{1, "2"},
+			expectedMsg: "map[int]bool can not contain a string key",
+		},
+		{
+			name:        "unsupported collection",
+			collection:  7,
+			items:       []interface{}{7},
+			expectedMsg: "type int does not contain items",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result := ContainsAll(testcase.collection, testcase.items...)()
+			if testcase.expected {
+				assertSuccess(t, result)
+			} else {
+				assertFailure(t, result, testcase.expectedMsg)
+			}
+		})
+	}
+}
+
+func TestContainsAny(t *testing.T) {
+	var testcases = []struct {
+		name        string
+		collection  interface{}
+		items       []interface{}
+		expected    bool
+		expectedMsg string
+	}{
+		{
+			name:       "slice contains one",
+			collection: []int{1, 2, 3},
+			items:      []interface{}{5, 3},
+			expected:   true,
+		},
+		{
+			name:        "slice contains none",
+			collection:  [2]int{1, 2},
+			items:       []interface{}{5, 3},
+			expectedMsg: "[1 2] does not contain any of: 5, 3",
+		},
+		{
+			name:        "string contains none",
+			collection:  "abc",
+			items:       []interface{}{"d", "e"},
+			expectedMsg: `abc does not contain any of: "d", "e"`,
+		},
+		{
+			name:        "no items",
+			collection:  "abc",
+			expectedMsg: "no items to find in collection",
+		},
+		{
+			name:        "nil collection",
+			collection:  nil,
+			items:       []interface{}{1},
+			expectedMsg: "nil does not contain items",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result := ContainsAny(testcase.collection, testcase.items...)()
+			if testcase.expected {
+				assertSuccess(t, result)
+			} else {
+				assertFailure(t, result, testcase.expectedMsg)
+			}
+		})
+	}
+}
+
+func TestContainsOrdered(t *testing.T) {
+	var testcases = []struct {
+		name        string
+		collection  interface{}
+		items       []interface{}
+		expectedMsg string
+	}{
+		{
+			name:       "string with substrings in order",
+			collection: "starting\nlistening on :80\nready\nstopped\n",
+			items:      []interface{}{"starting", "ready", "stopped"},
+		},
+		{
+			name:        "string with substrings out of order",
+			collection:  "ready\nstarting\n",
+			items:       []interface{}{"starting", "ready"},
+			expectedMsg: `string "ready\nstarting\n" does not contain "ready" after "starting" at offset 6`,
+		},
+		{
+			name:        "string matches do not overlap",
+			collection:  "abc",
+			items:       []interface{}{"ab", "bc"},
+			expectedMsg: `string "abc" does not contain "bc" after "ab" at offset 0`,
+		},
+		{
+			name:        "string missing first item",
+			collection:  "abc",
+			items:       []interface{}{"d"},
+			expectedMsg: `string "abc" does not contain "d"`,
+		},
+		{
+			name:        "string with non-string item",
+			collection:  "abc",
+			items:       []interface{}{1},
+			expectedMsg: "string may only contain strings",
+		},
+		{
+			name:       "slice with items in order",
+			collection: []interface{}{1, "a", 2, "b", 3},
+			items:      []interface{}{1, 2, 3},
+		},
+		{
+			name:       "slice with repeated items",
+			collection: []int{1, 2, 1},
+			items:      []interface{}{1, 1},
+		},
+		{
+			name:        "slice with items out of order",
+			collection:  []int{3, 2, 1},
+			items:       []interface{}{1, 2},
+			expectedMsg: "[3 2 1] does not contain 2 after 1 at index 2",
+		},
+		{
+			name:        "array missing first item",
+			collection:  [2]string{"a", "b"},
+			items:       []interface{}{"c"},
+			expectedMsg: `[a b] does not contain "c"`,
+		},
+		{
+			name:       "no items",
+			collection: []int{1},
+		},
+		{
+			name:        "map is not supported",
+			collection:  map[string]int{"a": 1},
+			items:       []interface{}{"a"},
+			expectedMsg: "type map[string]int does not support ordered items",
+		},
+		{
+			name:        "nil collection",
+			items:       []interface{}{"a"},
+			expectedMsg: "nil does not contain items",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result := ContainsOrdered(testcase.collection, testcase.items...)()
+			if testcase.expectedMsg == "" {
+				assertSuccess(t, result)
+				return
+			}
+			assertFailure(t, result, testcase.expectedMsg)
+		})
+	}
+}
+
+func TestContainsCount(t *testing.T) {
+	var testcases = []struct {
+		name        string
+		collection  interface{}
+		item        interface{}
+		count       int
+		expected    bool
+		expectedMsg string
+	}{
+		{
+			name:       "string with matching count",
+			collection: "ERROR one\nok\nERROR two\n",
+			item:       "ERROR",
+			count:      2,
+			expected:   true,
+		},
+		{
+			name:        "string count is non-overlapping",
+			collection:  "aaaa",
+			item:        "aa",
+			count:       3,
+			expectedMsg: `expected aaaa to contain "aa" 3 times, found 2`,
+		},
+		{
+			name:       "slice with matching count",
+			collection: []interface{}{1, "1", 1, 2},
+			item:       1,
+			count:      2,
+			expected:   true,
+		},
+		{
+			name:        "array with wrong count",
+			collection:  [3]string{"a", "b", "a"},
+			item:        "a",
+			count:       1,
+			expectedMsg: `expected [a b a] to contain "a" 1 times, found 2`,
+		},
+		{
+			name:       "item not found",
+			collection: []int{1, 2},
+			item:       3,
+			count:      0,
+			expected:   true,
+		},
+		{
+			name:        "map is not supported",
+			collection:  map[string]int{"a": 1},
+			item:        "a",
+			count:       1,
+			expectedMsg: "ContainsCount does not support maps",
+		},
+		{
+			name:        "string with non-string item",
+			collection:  "abc",
+			item:        1,
+			count:       1,
+			expectedMsg: "string may only contain strings",
+		},
+		{
+			name:        "empty substring",
+			collection:  "abc",
+			item:        "",
+			count:       0,
+			expectedMsg: "can not count an empty string",
+		},
+		{
+			name:        "nil collection",
+			collection:  nil,
+			item:        1,
+			expectedMsg: "nil does not contain items",
+		},
+		{
+			name:        "unsupported type",
+			collection:  7,
+			item:        7,
+			expectedMsg: "type int does not contain items",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result := ContainsCount(testcase.collection, testcase.item, testcase.count)()
+			if testcase.expected {
+				assertSuccess(t, result)
+			} else {
+				assertFailure(t, result, testcase.expectedMsg)
+			}
+		})
+	}
+}
+
+func TestMapSubset(t *testing.T) {
+	var testcases = []struct {
+		name            string
+		superset        interface{}
+		subset          interface{}
+		expected        bool
+		expectedMessage string
+	}{
+		{
+			name:     "subset",
+			superset: map[string]string{"a": "1", "b": "2", "c": "3"},
+			subset:   map[string]string{"a": "1", "c": "3"},
+			expected: true,
+		},
+		{
+			name:     "empty subset",
+			superset: map[string]string{"a": "1"},
+			subset:   map[string]string(nil),
+			expected: true,
+		},
+		{
+			name:     "deep values",
+			superset: map[int][]string{1: {"a"}, 2: {"b"}},
+			subset:   map[int][]string{2: {"b"}},
+			expected: true,
+		},
+		{
+			name:     "missing and different values",
+			superset: map[string]string{"a": "1", "c": "4"},
+			subset:   map[string]string{"a": "1", "b": "2", "c": "3"},
+			expectedMessage: `map[a:1 c:4] does not contain all of map[a:1 b:2 c:3]
+missing key "b"
+key "c": expected "3", got "4"`,
+		},
+		{
+			name:            "not maps",
+			superset:        map[string]string{},
+			subset:          []string{},
+			expectedMessage: "MapSubset requires two maps, got map[string]string and []string",
+		},
+		{
+			name:     "different value types",
+			superset: map[string]string{},
+			subset:   map[string]int{},
+			expectedMessage: "can not compare map[string]string to map[string]int, " +
+				"maps must be the same type",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result := MapSubset(testcase.superset, testcase.subset)()
+			if testcase.expected {
+				assertSuccess(t, result)
+			} else {
+				assertFailure(t, result, testcase.expectedMessage)
+			}
+		})
+	}
+}
+
+func TestContainsValue(t *testing.T) {
+	many := make(map[int]int)
+	for i := 0; i < 12; i++ {
+		many[i] = i * 10
+	}
+	var nilSlice []string
+
+	var testcases = []struct {
+		name        string
+		m           interface{}
+		value       interface{}
+		expectedMsg string
+	}{
+		{
+			name:  "contains value",
+			m:     map[string]int{"a": 1, "b": 2},
+			value: 2,
+		},
+		{
+			name:  "deep equal value",
+			m:     map[string][]string{"a": {"x", "y"}},
+			value: []string{"x", "y"},
+		},
+		{
+			name:  "interface value",
+			m:     map[string]interface{}{"a": 1, "b": "two"},
+			value: "two",
+		},
+		{
+			name:  "nil value",
+			m:     map[string][]string{"a": nil},
+			value: nilSlice,
+		},
+		{
+			name:  "untyped nil pointer value",
+			m:     map[string]*int{"a": nil},
+			value: nil,
+		},
+		{
+			name:  "untyped nil interface value",
+			m:     map[string]interface{}{"a": nil},
+			value: nil,
+		},
+		{
+			name:        "untyped nil not in map",
+			m:           map[string][]string{"a": {"x"}},
+			value:       nil,
+			expectedMsg: `map does not contain value <nil>, values are: []string{"x"}`,
+		},
+		{
+			name:        "missing value",
+			m:           map[string]string{"b": "two", "a": "one"},
+			value:       "three",
+			expectedMsg: `map does not contain value "three", values are: "one", "two"`,
+		},
+		{
+			name:        "empty map",
+			m:           map[string]string{},
+			value:       "one",
+			expectedMsg: `map does not contain value "one", values are: `,
+		},
+		{
+			name:  "bounded list of values",
+			m:     many,
+			value: 5,
+			expectedMsg: "map does not contain value 5, values are: " +
+				"0, 10, 100, 110, 20, 30, 40, 50, 60, 70, ... (2 more)",
+		},
+		{
+			name:        "wrong value type",
+			m:           map[string]int{"a": 1},
+			value:       "1",
+			expectedMsg: "map[string]int can not contain a string value",
+		},
+		{
+			name:        "untyped nil",
+			m:           map[string]int{"a": 1},
+			value:       nil,
+			expectedMsg: "map[string]int can not contain a nil value",
+		},
+		{
+			name:        "not a map",
+			m:           []int{1},
+			value:       1,
+			expectedMsg: "type []int is not a map",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result := ContainsValue(testcase.m, testcase.value)()
+			if testcase.expectedMsg == "" {
+				assertSuccess(t, result)
+				return
+			}
+			assertFailure(t, result, testcase.expectedMsg)
+		})
+	}
+}
+
+func TestSorted(t *testing.T) {
+	t.Run("sorted", func(t *testing.T) {
+		seq := []float64{1, 1.5, 1.5, 3}
+		assertSuccess(t, Sorted(seq, func(i, j int) bool { return seq[i] < seq[j] })())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assertSuccess(t, SortedInts(nil)())
+	})
+
+	t.Run("array", func(t *testing.T) {
+		seq := [3]string{"c", "b", "a"}
+		assertSuccess(t, Sorted(seq, func(i, j int) bool { return seq[i] > seq[j] })())
+	})
+
+	t.Run("not sorted", func(t *testing.T) {
+		result := SortedInts([]int{1, 5, 3, 2})()
+		assertFailure(t, result,
+			"[1 5 3 2] is not sorted: index 1 (5) should not be before index 2 (3)")
+	})
+
+	t.Run("strings not sorted", func(t *testing.T) {
+		result := SortedStrings([]string{"b", "a"})()
+		assertFailure(t, result,
+			"[b a] is not sorted: index 0 (b) should not be before index 1 (a)")
+	})
+
+	t.Run("not a slice", func(t *testing.T) {
+		result := Sorted("abc", func(i, j int) bool { return false })()
+		assertFailure(t, result, "type string is not a slice or array")
+	})
+
+	t.Run("less panics", func(t *testing.T) {
+		seq := []int{1, 2}
+		result := Sorted(seq, func(i, j int) bool { return seq[i+j+1] < 0 })()
+		assertFailure(t, result, "failed to check order of []int: "+
+			"runtime error: index out of range [2] with length 2")
+	})
+}
+
+func TestMapValuesEqual(t *testing.T) {
+	x := map[string]int{"a": 1, "b": 2, "c": 3, "x": 10}
+	y := map[string]int{"a": 1, "b": 2, "d": 4, "x": 11}
+
+	t.Run("listed keys equal", func(t *testing.T) {
+		assertSuccess(t, MapValuesEqual(x, y, "a", "b")())
+	})
+
+	t.Run("no keys", func(t *testing.T) {
+		assertSuccess(t, MapValuesEqual(x, y)())
+	})
+
+	t.Run("missing and different", func(t *testing.T) {
+		result := MapValuesEqual(x, y, "a", "c", "d", "e", "x")()
+		expected := `map values are not equal
+key "c": missing in y
+key "d": missing in x
+key "e": missing in x and y
+key "x": 10 (x) != 11 (y)`
+		assertFailure(t, result, expected)
+	})
+
+	t.Run("wrong key type", func(t *testing.T) {
+		result := MapValuesEqual(x, y, 1)()
+		assertFailure(t, result, "map[string]int can not contain a int key")
+	})
+
+	t.Run("different map types", func(t *testing.T) {
+		result := MapValuesEqual(x, map[string]string{}, "a")()
+		assertFailure(t, result,
+			"can not compare map[string]int to map[string]string, maps must be the same type")
+	})
+
+	t.Run("not maps", func(t *testing.T) {
+		result := MapValuesEqual(x, nil, "a")()
+		assertFailure(t, result,
+			"MapValuesEqual requires two maps, got map[string]int and <nil>")
+	})
+}
@@ -2,12 +2,19 @@
 package cmp // import "gotest.tools/assert/cmp"
 
 import (
+	"bytes"
 	"fmt"
+	"math"
+	"path"
 	"reflect"
 	"regexp"
+	"runtime"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"gotest.tools/v3/internal/format"
 )
 
@@ -38,21 +45,526 @@ func DeepEqual(x, y interface{}, opts ...cmp.Option) Comparison {
 	}
 }
 
+// DeepEqualBounded compares x and y using DeepEqual, but limits the failure
+// message to the first maxDiffs differences. A difference is a group of
+// consecutive changed lines in the diff. When differences are omitted the
+// message ends with the number of differences which were omitted, and the total
+// number of differences. DeepEqualBounded fails if maxDiffs is less than 1.
+//
+// DeepEqualBounded is useful for comparing large values, such as slices with
+// many thousands of elements, where the full diff would be too large to read.
+func DeepEqualBounded(x, y interface{}, maxDiffs int, opts ...cmp.Option) Comparison {
+	return func() (result Result) {
+		defer func() {
+			if panicmsg, handled := handleCmpPanic(recover()); handled {
+				result = ResultFailure(panicmsg)
+			}
+		}()
+		if maxDiffs < 1 {
+			return ResultFailure(fmt.Sprintf("invalid maxDiffs %d, maxDiffs must be at least 1", maxDiffs))
+		}
+		// cmp.Diff compares the values and builds the report in a single pass,
+		// so the values are only compared once.
+		diff := cmp.Diff(x, y, opts...)
+		if diff == "" {
+			return ResultSuccess
+		}
+		return multiLineDiffResult(truncateDiff(diff, maxDiffs))
+	}
+}
+
+// truncateDiff removes all lines of diff after the end of the first maxDiffs
+// groups of changed lines.
+func truncateDiff(diff string, maxDiffs int) string {
+	lines := strings.SplitAfter(diff, "\n")
+	var total, cut int
+	var inDiff bool
+	for i, line := range lines {
+		changed := strings.HasPrefix(line, "-") || strings.HasPrefix(line, "+")
+		if changed && !inDiff {
+			total++
+			if total == maxDiffs+1 {
+				cut = i
+			}
+		}
+		inDiff = changed
+	}
+	if total <= maxDiffs {
+		return diff
+	}
+	return fmt.Sprintf("%s... (%d more differences omitted, %d total)\n",
+		strings.Join(lines[:cut], ""), total-maxDiffs, total)
+}
+
+// WithMaxValueWidth returns an option for DeepEqual which limits the width of
+// long strings and byte slices in the diff to n runes. When a string or byte
+// slice is longer than n and the values are different, only the n runes around
+// the first difference are shown, and the truncated parts are replaced by an
+// ellipsis (…). Values which are equal are not changed.
+//
+// Example:
+//   assert.DeepEqual(t, actual, expected, cmp.WithMaxValueWidth(40))
+func WithMaxValueWidth(n int) cmp.Option {
+	windows := &truncateWindows{start: make(map[string]int)}
+	return cmp.Options{
+		cmp.FilterValues(func(x, y string) bool {
+			return windows.add(x, y, n)
+		}, cmp.Transformer("truncate", func(s string) string {
+			return windows.truncate(s, n)
+		})),
+		cmp.FilterValues(func(x, y []byte) bool {
+			return windows.add(string(x), string(y), n)
+		}, cmp.Transformer("truncate", func(b []byte) string {
+			return windows.truncate(string(b), n)
+		})),
+	}
+}
+
+// truncateWindows stores the index of the first rune to show for each value
+// truncated by WithMaxValueWidth. The filter of the option records the index
+// for both values, and the transformer, which only receives one value, uses
+// the recorded index. go-cmp may call the transformer from another goroutine,
+// so the map is guarded by a mutex.
+type truncateWindows struct {
+	mu    sync.Mutex
+	start map[string]int
+}
+
+// add records the start of the window around the first difference between x
+// and y, and returns true if either value needs to be truncated.
+func (w *truncateWindows) add(x, y string, n int) bool {
+	if x == y || n <= 0 {
+		return false
+	}
+	xRunes, yRunes := []rune(x), []rune(y)
+	if len(xRunes) <= n && len(yRunes) <= n {
+		return false
+	}
+	index := 0
+	for index < len(xRunes) && index < len(yRunes) && xRunes[index] == yRunes[index] {
+		index++
+	}
+	start := index - n/2
+	if start < 0 {
+		start = 0
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.start[x], w.start[y] = start, start
+	return true
+}
+
+func (w *truncateWindows) truncate(s string, n int) string {
+	w.mu.Lock()
+	start := w.start[s]
+	w.mu.Unlock()
+
+	runes := []rune(s)
+	if start > len(runes) {
+		start = len(runes)
+	}
+	end := start + n
+	if end > len(runes) {
+		end = len(runes)
+	}
+	truncated := string(runes[start:end])
+	if start > 0 {
+		truncated = "…" + truncated
+	}
+	if end < len(runes) {
+		truncated += "…"
+	}
+	return truncated
+}
+
+// FirstDiffPath compares x and y using DeepEqual, but the failure message only
+// includes the path and values of the first difference, instead of the full
+// diff. For example:
+//   {cmp.config}.Servers[2].Name: "alpha" != "beta"
+//
+// FirstDiffPath is useful for large values where the full diff is too long to
+// read. The order in which differences are found in maps is unspecified.
+func FirstDiffPath(x, y interface{}, opts ...cmp.Option) Comparison {
+	return func() (result Result) {
+		defer func() {
+			if panicmsg, handled := handleCmpPanic(recover()); handled {
+				result = ResultFailure(panicmsg)
+			}
+		}()
+		reporter := &firstDiffReporter{}
+		if cmp.Equal(x, y, cmp.Options(opts), cmp.Reporter(reporter)) {
+			return ResultSuccess
+		}
+		return ResultFailure(reporter.diff)
+	}
+}
+
+// firstDiffReporter is a go-cmp Reporter which records the path and values of
+// the first node which is not equal.
+type firstDiffReporter struct {
+	path cmp.Path
+	diff string
+}
+
+func (r *firstDiffReporter) PushStep(step cmp.PathStep) {
+	r.path = append(r.path, step)
+}
+
+func (r *firstDiffReporter) Report(result cmp.Result) {
+	if result.Equal() || r.diff != "" {
+		return
+	}
+	vx, vy := r.path.Last().Values()
+	r.diff = fmt.Sprintf("%#v: %s != %s", r.path, formatReflectValue(vx), formatReflectValue(vy))
+}
+
+func (r *firstDiffReporter) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+// formatReflectValue formats value using %#v, or as <missing> if the value is
+// not valid, for example an element which was only in one of the slices.
+func formatReflectValue(value reflect.Value) string {
+	if !value.IsValid() {
+		return "<missing>"
+	}
+	return fmt.Sprintf("%#v", value)
+}
+
+// DeepEqualSemantic compares x and y using DeepEqual, with default options for
+// types which are not compared correctly by DeepEqual:
+//
+//   - time.Time values are compared using time.Time.Equal.
+//   - error values are equal if either error wraps the other, or if
+//     both errors have the same message.
+//   - an empty slice or map is equal to a nil slice or map.
+//
+// Options in opts take precedence over the default options. A default option
+// is not used if it conflicts with one of opts, for example when opts contains
+// a different cmp.Comparer for time.Time.
+func DeepEqualSemantic(x, y interface{}, opts ...cmp.Option) Comparison {
+	return func() (result Result) {
+		runWithDefaults(x, y, semanticDefaults(), opts, func(options []cmp.Option) {
+			result = DeepEqual(x, y, options...)()
+		})
+		return result
+	}
+}
+
+// semanticDefaults returns the default options used by DeepEqualSemantic.
+func semanticDefaults() []cmp.Option {
+	return []cmp.Option{
+		cmp.Comparer(func(x, y time.Time) bool { return x.Equal(y) }),
+		cmp.Comparer(errorsEqual),
+		cmpopts.EquateEmpty(),
+	}
+}
+
+// runWithDefaults calls compare with defaults followed by opts. If go-cmp
+// panics because one of the defaults and one of opts apply to the same value,
+// compare is called again without the defaults which conflict with opts.
+//
+// The conflicting defaults are only searched for after the panic, so that the
+// values, and any Comparer or Transformer in opts, are only used once when
+// there is no conflict.
+func runWithDefaults(x, y interface{}, defaults, opts []cmp.Option, compare func([]cmp.Option)) {
+	options := append(append([]cmp.Option{}, defaults...), opts...)
+	recovered, panicked := recoverPanic(func() { compare(options) })
+	if !panicked {
+		return
+	}
+	if !isAmbiguousPanic(recovered) {
+		panic(recovered)
+	}
+	var kept []cmp.Option
+	for _, option := range defaults {
+		candidate := append(append([]cmp.Option{option}, kept...), opts...)
+		if !isAmbiguous(x, y, candidate) {
+			kept = append(kept, option)
+		}
+	}
+	compare(append(kept, opts...))
+}
+
+func isAmbiguousPanic(recovered interface{}) bool {
+	return strings.Contains(fmt.Sprint(recovered), "ambiguous set of applicable options")
+}
+
+// isAmbiguous returns true if go-cmp panics because more than one of opts
+// applies to the same value. Any other panic is ignored, so that it can be
+// handled by DeepEqual.
+func isAmbiguous(x, y interface{}, opts []cmp.Option) (ambiguous bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			ambiguous = isAmbiguousPanic(r)
+		}
+	}()
+	cmp.Equal(x, y, opts...)
+	return false
+}
+
+func errorsEqual(x, y error) bool {
+	switch {
+	case x == nil || y == nil:
+		return x == y
+	case errorWraps(x, y) || errorWraps(y, x):
+		return true
+	}
+	return x.Error() == y.Error()
+}
+
+// errorWraps returns true if target is err, or any error wrapped by err.
+func errorWraps(err, target error) bool {
+	comparable := reflect.TypeOf(target).Comparable()
+	for _, e := range errorChain(err) {
+		if comparable && e == target {
+			return true
+		}
+		if is, ok := e.(interface{ Is(error) bool }); ok && is.Is(target) {
+			return true
+		}
+	}
+	return false
+}
+
+// DeepEqualApprox compares x and y using DeepEqual, but every float64 and
+// float32 value, including values of named types such as
+// "type Celsius float64", is equal if the absolute difference between the
+// values is at most delta. Options in opts take precedence, so a cmp.Comparer
+// for float64 in opts replaces the default tolerance for values of kind
+// float64.
+//
+// The failure message includes the diff, followed by the path and difference
+// of each float value which differed by more than delta.
+//
+// Example:
+//   assert.Check(t, cmp.DeepEqualApprox(result, expected, 1e-9))
+func DeepEqualApprox(x, y interface{}, delta float64, opts ...cmp.Option) Comparison {
+	return func() (result Result) {
+		defer func() {
+			if panicmsg, handled := handleCmpPanic(recover()); handled {
+				result = ResultFailure(panicmsg)
+			}
+		}()
+		if err := checkTolerance("delta", delta); err != nil {
+			return ResultFailure(err.Error())
+		}
+		withinDelta := cmp.Comparer(func(x, y interface{}) bool {
+			return math.Abs(reflect.ValueOf(x).Float()-reflect.ValueOf(y).Float()) <= delta
+		})
+		defaults := []cmp.Option{
+			cmp.FilterValues(isKindPair(reflect.Float64), withinDelta),
+			cmp.FilterValues(isKindPair(reflect.Float32), withinDelta),
+		}
+		var reporter *floatDeltaReporter
+		var diff string
+		runWithDefaults(x, y, defaults, opts, func(options []cmp.Option) {
+			reporter = &floatDeltaReporter{delta: delta}
+			diff = cmp.Diff(x, y, append(options, cmp.Reporter(reporter))...)
+		})
+		if diff == "" {
+			return ResultSuccess
+		}
+		if len(reporter.exceeded) > 0 {
+			diff += fmt.Sprintf("\nfloat values which differ by more than %v:\n  %s",
+				delta, strings.Join(reporter.exceeded, "\n  "))
+		}
+		return multiLineDiffResult(diff)
+	}
+}
+
+// isKindPair returns a go-cmp filter which matches values of kind.
+func isKindPair(kind reflect.Kind) func(x, y interface{}) bool {
+	return func(x, y interface{}) bool {
+		return reflect.ValueOf(x).Kind() == kind && reflect.ValueOf(y).Kind() == kind
+	}
+}
+
+// floatDeltaReporter is a go-cmp Reporter which records the path and values of
+// each float which differs by more than delta.
+type floatDeltaReporter struct {
+	path     cmp.Path
+	delta    float64
+	exceeded []string
+}
+
+func (r *floatDeltaReporter) PushStep(step cmp.PathStep) {
+	r.path = append(r.path, step)
+}
+
+func (r *floatDeltaReporter) Report(result cmp.Result) {
+	if result.Equal() {
+		return
+	}
+	vx, vy := r.path.Last().Values()
+	if vx.IsValid() && vx.Kind() == reflect.Interface {
+		vx, vy = vx.Elem(), vy.Elem()
+	}
+	if !vx.IsValid() || !vy.IsValid() || vx.Kind() != vy.Kind() {
+		return
+	}
+	switch vx.Kind() {
+	case reflect.Float32, reflect.Float64:
+	default:
+		return
+	}
+	diff := math.Abs(vx.Float() - vy.Float())
+	if !(diff <= r.delta) {
+		r.exceeded = append(r.exceeded, fmt.Sprintf(
+			"%#v: %v != %v (delta %v)", r.path, vx, vy, diff))
+	}
+}
+
+func (r *floatDeltaReporter) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+// DeepEqualIgnore compares x and y using DeepEqual, ignoring the named fields of
+// the struct type of x. Fields of nested structs can be named using a dot
+// separated path, for example "Metadata.CreatedAt".
+//
+// DeepEqualIgnore fails if x is not a struct or a pointer to a struct, or if
+// any of the fields do not exist, so that a misspelled field name does not
+// silently ignore nothing.
+func DeepEqualIgnore(x, y interface{}, fields ...string) Comparison {
+	return func() Result {
+		typ := reflect.TypeOf(x)
+		if typ != nil && typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		if typ == nil || typ.Kind() != reflect.Struct {
+			return ResultFailure(fmt.Sprintf(
+				"DeepEqualIgnore requires a struct or a pointer to a struct, got %T", x))
+		}
+		for _, field := range fields {
+			if err := checkFieldPath(typ, field); err != nil {
+				return ResultFailure(err.Error())
+			}
+		}
+		opt, err := ignoreFields(typ, fields)
+		if err != nil {
+			return ResultFailure(err.Error())
+		}
+		return DeepEqual(x, y, opt)()
+	}
+}
+
+// ignoreFields returns cmpopts.IgnoreFields for the fields of typ. An error is
+// returned if cmpopts rejects the fields, for example because a field name is
+// ambiguous.
+func ignoreFields(typ reflect.Type, fields []string) (opt cmp.Option, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not ignore fields of %s: %v", typ, r)
+		}
+	}()
+	return cmpopts.IgnoreFields(reflect.Zero(typ).Interface(), fields...), nil
+}
+
+// checkFieldPath returns an error if the dot separated field path does not
+// exist on the struct type typ.
+func checkFieldPath(typ reflect.Type, path string) error {
+	current := typ
+	for _, name := range strings.Split(path, ".") {
+		for current.Kind() == reflect.Ptr {
+			current = current.Elem()
+		}
+		if current.Kind() != reflect.Struct {
+			return fmt.Errorf("can not ignore field %q of %s, %s is not a struct",
+				path, typ, current)
+		}
+		field, ok := current.FieldByName(name)
+		if !ok {
+			return fmt.Errorf("can not ignore field %q, type %s has no field %q",
+				path, current, name)
+		}
+		current = field.Type
+	}
+	return nil
+}
+
+var panicHandlers = struct {
+	sync.RWMutex
+	handlers []func(r interface{}) (string, bool)
+}{}
+
+// RegisterPanicHandler adds a handler for panics from go-cmp during DeepEqual.
+// Handlers are called in the order they were registered, with the value passed
+// to panic, before the default handling. If a handler returns true, the
+// comparison fails with the message returned by the handler, instead of
+// panicking. This can be used to report a panic from a custom Comparer as a
+// failure.
+//
+// RegisterPanicHandler is safe to call from multiple goroutines.
+func RegisterPanicHandler(handler func(r interface{}) (string, bool)) {
+	panicHandlers.Lock()
+	defer panicHandlers.Unlock()
+	panicHandlers.handlers = append(panicHandlers.handlers, handler)
+}
+
 func handleCmpPanic(r interface{}) (string, bool) {
 	if r == nil {
 		return "", false
 	}
-	panicmsg, ok := r.(string)
-	if !ok {
+	panicHandlers.RLock()
+	handlers := panicHandlers.handlers
+	panicHandlers.RUnlock()
+	for _, handler := range handlers {
+		if msg, ok := handler(r); ok {
+			return msg, true
+		}
+	}
+
+	var panicmsg string
+	switch value := r.(type) {
+	case string:
+		panicmsg = value
+	case error:
+		panicmsg = value.Error()
+	default:
 		panic(r)
 	}
 	switch {
+	// All versions of go-cmp start the message with this prefix, a panic from
+	// a Comparer or Transformer may include the same words in another position.
 	case strings.HasPrefix(panicmsg, "cannot handle unexported field"):
-		return panicmsg, true
+		return panicmsg + "\n" + unexportedFieldAdvice(panicmsg), true
 	}
 	panic(r)
 }
 
+var (
+	// go-cmp >= 0.4 includes the qualified name of the struct type on the line
+	// after the path, for example:
+	//   cannot handle unexported field at {pkg.T}.f:
+	//   	"example.com/pkg".T
+	unexportedFieldTypePattern = regexp.MustCompile(
+		`unexported field at [^\n]*:\n\t"([^"]*)"\.(\w+)`)
+	// Earlier versions only include the path, so the struct type is only known
+	// when the field is on the root value, for example:
+	//   cannot handle unexported field: {pkg.T}.f
+	unexportedFieldPathPattern = regexp.MustCompile(
+		`^cannot handle unexported field: {([\w.]+)}\.\w+(\n|$)`)
+)
+
+// unexportedFieldAdvice returns a suggestion for how to fix a DeepEqual of a
+// struct with unexported fields, using the name of the struct type if it can
+// be found in the go-cmp panic message.
+func unexportedFieldAdvice(panicmsg string) string {
+	var typeName string
+	if match := unexportedFieldTypePattern.FindStringSubmatch(panicmsg); match != nil {
+		typeName = path.Base(match[1]) + "." + match[2]
+	} else if match := unexportedFieldPathPattern.FindStringSubmatch(panicmsg); match != nil {
+		typeName = match[1]
+	}
+	if typeName == "" {
+		return "use cmpopts.IgnoreUnexported to ignore the unexported fields, " +
+			"or a cmp.Comparer to compare values of the struct"
+	}
+	return fmt.Sprintf("use cmpopts.IgnoreUnexported(%[1]s{}) to ignore the unexported "+
+		"fields of %[1]s, or a cmp.Comparer to compare values of %[1]s", typeName)
+}
+
 func toResult(success bool, msg string) Result {
 	if success {
 		return ResultSuccess
@@ -64,7 +576,9 @@ func toResult(success bool, msg string) Result {
 // regexp pattern.
 type RegexOrPattern interface{}
 
-// Regexp succeeds if value v matches regular expression re.
+// Regexp succeeds if value v matches regular expression re. The match may be
+// anywhere in v, use ^ and $ to anchor the pattern. If re is a string which is
+// not a valid pattern the compile error is returned as the failure message.
 //
 // Example:
 //   assert.Assert(t, cmp.Regexp("^[0-9a-f]{32}$", str))
@@ -95,27 +609,168 @@ func Regexp(re RegexOrPattern, v string) Comparison {
 
 // Equal succeeds if x == y. See assert.Equal for full documentation.
 func Equal(x, y interface{}) Comparison {
-	return func() Result {
+	return func() (result Result) {
+		defer func() {
+			if isUncomparablePanic(recover()) {
+				result = ResultFailure(uncomparableMessage(x, y))
+			}
+		}()
 		switch {
 		case x == y:
 			return ResultSuccess
-		case isMultiLineStringCompare(x, y):
+		case isDiffStringCompare(x, y):
 			diff := format.UnifiedDiff(format.DiffConfig{A: x.(string), B: y.(string)})
 			return multiLineDiffResult(diff)
 		}
 		return ResultFailureTemplate(`
-			{{- printf "%v" .Data.x}} (
+			{{- formatValue .Data.x }} (
 				{{- with callArg 0 }}{{ formatNode . }} {{end -}}
 				{{- printf "%T" .Data.x -}}
-			) != {{ printf "%v" .Data.y}} (
+			) != {{ formatValue .Data.y }} (
 				{{- with callArg 1 }}{{ formatNode . }} {{end -}}
 				{{- printf "%T" .Data.y -}}
-			)`,
-			map[string]interface{}{"x": x, "y": y})
+			)
+			{{- if .Data.showGoSyntax }}
+x as Go syntax: {{ .Data.xGoSyntax }}
+y as Go syntax: {{ .Data.yGoSyntax }}
+			{{- end }}`,
+			map[string]interface{}{
+				"x":            x,
+				"y":            y,
+				"showGoSyntax": showGoSyntax(x, y),
+				"xGoSyntax":    goSyntax(x),
+				"yGoSyntax":    goSyntax(y),
+			})
 	}
 }
 
-func isMultiLineStringCompare(x, y interface{}) bool {
+// isUncomparablePanic returns true if r is the runtime error from comparing
+// values of a type which can not be compared with ==. Any other panic is
+// re-raised.
+func isUncomparablePanic(r interface{}) bool {
+	if r == nil {
+		return false
+	}
+	if err, ok := r.(runtime.Error); ok && strings.Contains(err.Error(), "comparing uncomparable type") {
+		return true
+	}
+	panic(r)
+}
+
+// uncomparableMessage returns the failure message for x and y when comparing
+// them with == panicked. If the uncomparable value is nested in x or y the
+// message includes the path and type of that value.
+func uncomparableMessage(x, y interface{}) string {
+	for _, value := range []interface{}{x, y} {
+		path, typ, ok := findUncomparable(reflect.ValueOf(value), fmt.Sprintf("{%T}", value))
+		switch {
+		case !ok:
+			continue
+		case typ == reflect.TypeOf(value):
+			return fmt.Sprintf(
+				"values of type %T can not be compared with ==, use cmp.DeepEqual instead", value)
+		default:
+			return fmt.Sprintf(
+				"values of type %T can not be compared with ==, %s has uncomparable type %s, use cmp.DeepEqual instead",
+				value, path, typ)
+		}
+	}
+	return fmt.Sprintf("values of type %T can not be compared with ==, use cmp.DeepEqual instead", x)
+}
+
+// findUncomparable returns the path and type of the first value in value
+// which can not be compared with ==. Pointers are compared by address, so the
+// values they point to are not searched.
+func findUncomparable(value reflect.Value, path string) (string, reflect.Type, bool) {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Func:
+		return path, value.Type(), true
+	case reflect.Interface:
+		if value.IsNil() {
+			return "", nil, false
+		}
+		return findUncomparable(value.Elem(), path)
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			fieldPath := path + "." + value.Type().Field(i).Name
+			if p, typ, ok := findUncomparable(value.Field(i), fieldPath); ok {
+				return p, typ, true
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if p, typ, ok := findUncomparable(value.Index(i), fmt.Sprintf("%s[%d]", path, i)); ok {
+				return p, typ, true
+			}
+		}
+	}
+	return "", nil, false
+}
+
+// showGoSyntax returns true if either x or y is an error or a fmt.Stringer,
+// both values print the same with %v, and the Go syntax of the values is
+// different. Two errors or Stringers with the same message may not be equal,
+// so the Go syntax helps to show how they are different.
+func showGoSyntax(x, y interface{}) bool {
+	if !isErrorOrStringer(x) && !isErrorOrStringer(y) {
+		return false
+	}
+	return fmt.Sprintf("%v", x) == fmt.Sprintf("%v", y) && goSyntax(x) != goSyntax(y)
+}
+
+func isErrorOrStringer(value interface{}) bool {
+	switch value.(type) {
+	case error, fmt.Stringer:
+		return true
+	}
+	return false
+}
+
+// goSyntax returns value formatted with %#v. The address of a pointer is also
+// included, because two pointers to values with the same fields, like two
+// errors from errors.New with the same message, have the same Go syntax.
+func goSyntax(value interface{}) string {
+	if reflect.ValueOf(value).Kind() == reflect.Ptr {
+		return fmt.Sprintf("%#v at %p", value, value)
+	}
+	return fmt.Sprintf("%#v", value)
+}
+
+// PtrEqual succeeds if x and y are pointers of the same type, and the values
+// they point to are equal. The values are compared using reflect.DeepEqual().
+// Two nil pointers are equal.
+//
+// Use PtrEqual instead of Equal, which compares the address of pointers.
+func PtrEqual(x, y interface{}) Comparison {
+	return func() Result {
+		xValue, yValue := reflect.ValueOf(x), reflect.ValueOf(y)
+		if xValue.Kind() != reflect.Ptr || yValue.Kind() != reflect.Ptr {
+			return ResultFailure(fmt.Sprintf("PtrEqual requires pointers, got %T and %T", x, y))
+		}
+		if xValue.Type() != yValue.Type() {
+			return ResultFailure(fmt.Sprintf("can not compare %T to %T", x, y))
+		}
+		switch {
+		case xValue.IsNil() && yValue.IsNil():
+			return ResultSuccess
+		case xValue.IsNil(), yValue.IsNil():
+			return ResultFailure(fmt.Sprintf(
+				"one pointer is nil: %v (%T) != %v (%T)", x, x, y, y))
+		}
+
+		xElem, yElem := xValue.Elem().Interface(), yValue.Elem().Interface()
+		return toResult(reflect.DeepEqual(xElem, yElem), fmt.Sprintf("%v (%T) != %v (%T), values of pointers are not equal",
+			xElem, xElem, yElem, yElem))
+	}
+}
+
+// longStringLength is the length at which a string is considered too long to
+// be read easily in the single line failure message of Equal.
+const longStringLength = 80
+
+// isDiffStringCompare returns true if x and y are both strings, and either of
+// them is multi-line or long enough that a diff is easier to read.
+func isDiffStringCompare(x, y interface{}) bool {
 	strX, ok := x.(string)
 	if !ok {
 		return false
@@ -124,10 +779,32 @@ func isMultiLineStringCompare(x, y interface{}) bool {
 	if !ok {
 		return false
 	}
-	return strings.Contains(strX, "\n") || strings.Contains(strY, "\n")
+	switch {
+	case strings.Contains(strX, "\n"), strings.Contains(strY, "\n"):
+		return true
+	case len(strX) > longStringLength, len(strY) > longStringLength:
+		return true
+	}
+	return false
+}
+
+// ColorDiff enables ANSI colors in the diffs included in failure messages,
+// such as the diffs from DeepEqual and Equal. Colors are only used when stdout
+// is a terminal. Colors may also be enabled or disabled using the
+// GOTESTTOOLS_COLOR environment variable. A value of "1" enables colors when
+// stdout is a terminal, "always" enables colors even when it is not a
+// terminal, and "0" disables colors.
+var ColorDiff = false
+
+func colorDiff(diff string) string {
+	if !format.ColorEnabled(ColorDiff) {
+		return diff
+	}
+	return format.ColorDiff(diff)
 }
 
 func multiLineDiffResult(diff string) Result {
+	diff = colorDiff(diff)
 	return ResultFailureTemplate(`
 --- {{ with callArg 0 }}{{ formatNode . }}{{else}}←{{end}}
 +++ {{ with callArg 1 }}{{ formatNode . }}{{else}}→{{end}}
@@ -135,24 +812,133 @@ func multiLineDiffResult(diff string) Result {
 		map[string]interface{}{"diff": diff})
 }
 
-// Len succeeds if the sequence has the expected length.
+// Len succeeds if the sequence has the expected length. If seq is a pointer
+// the length of the value it points to is used, so a pointer to a slice, array,
+// map, or string may be passed as seq.
 func Len(seq interface{}, expected int) Comparison {
-	return func() (result Result) {
-		defer func() {
-			if e := recover(); e != nil {
-				result = ResultFailure(fmt.Sprintf("type %T does not have a length", seq))
-			}
-		}()
-		value := reflect.ValueOf(seq)
-		length := value.Len()
+	return func() Result {
+		value, length, err := lengthOf(seq)
+		if err != nil {
+			return ResultFailure(err.Error())
+		}
 		if length == expected {
 			return ResultSuccess
 		}
-		msg := fmt.Sprintf("expected %s (length %d) to have length %d", seq, length, expected)
+		msg := fmt.Sprintf("expected %s (length %d) to have length %d",
+			value.Interface(), length, expected)
 		return ResultFailure(msg)
 	}
 }
 
+// LenBetween succeeds if the length of the sequence is between min and max,
+// inclusive. See Len for the supported types of seq.
+//
+// LenBetween fails if min is greater than max.
+func LenBetween(seq interface{}, min, max int) Comparison {
+	return func() Result {
+		if min > max {
+			return ResultFailure(fmt.Sprintf("invalid range: min %d is greater than max %d", min, max))
+		}
+		_, length, err := lengthOf(seq)
+		if err != nil {
+			return ResultFailure(err.Error())
+		}
+		return toResult(min <= length && length <= max,
+			fmt.Sprintf("expected length between %d and %d, got %d", min, max, length))
+	}
+}
+
+// lengthOf returns the value of seq and its length. If seq is a pointer the
+// value it points to is returned.
+func lengthOf(seq interface{}) (value reflect.Value, length int, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = noLengthError(seq)
+		}
+	}()
+	value = reflect.ValueOf(seq)
+	if value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	return value, value.Len(), nil
+}
+
+// noLengthError returns an error for a value which does not have a length,
+// with a suggestion for a more appropriate comparison when one exists.
+func noLengthError(seq interface{}) error {
+	msg := fmt.Sprintf("type %T does not have a length", seq)
+	value := reflect.ValueOf(seq)
+	if value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr, reflect.Float32, reflect.Float64,
+		reflect.Complex64, reflect.Complex128:
+		return fmt.Errorf("%s, use cmp.Equal for comparing %ss", msg, value.Kind())
+	case reflect.Struct:
+		return fmt.Errorf("%s, use cmp.DeepEqual for comparing structs", msg)
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// Empty succeeds if obj is a string, slice, map, array, or channel with a
+// length of 0. A nil value or nil pointer is also considered empty. Like Len,
+// a pointer to a string, slice, map, array, or channel is dereferenced, so
+// the length of the value it points to is used. A pointer to any other type is
+// empty only if it is nil.
+func Empty(obj interface{}) Comparison {
+	return func() Result {
+		empty, err := isEmpty(obj)
+		if err != nil {
+			return ResultFailure(err.Error())
+		}
+		return toResult(empty, fmt.Sprintf("expected %v (%T) to be empty", obj, obj))
+	}
+}
+
+// NotEmpty succeeds if obj is a string, slice, map, array, or channel with a
+// length greater than 0, or a pointer which is not nil. Pointers are handled
+// the same way as Empty, so a pointer to an empty slice is empty.
+func NotEmpty(obj interface{}) Comparison {
+	return func() Result {
+		empty, err := isEmpty(obj)
+		if err != nil {
+			return ResultFailure(err.Error())
+		}
+		return toResult(!empty, fmt.Sprintf("expected %v (%T) to not be empty", obj, obj))
+	}
+}
+
+func isEmpty(obj interface{}) (empty bool, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("type %T does not have a length", obj)
+		}
+	}()
+	value := reflect.ValueOf(obj)
+	switch value.Kind() {
+	case reflect.Invalid:
+		return true, nil
+	case reflect.Ptr:
+		if value.IsNil() || !hasLength(value.Elem().Kind()) {
+			return value.IsNil(), nil
+		}
+		value = value.Elem()
+	}
+	return value.Len() == 0, nil
+}
+
+func hasLength(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array, reflect.Chan:
+		return true
+	}
+	return false
+}
+
 // Contains succeeds if item is in collection. Collection may be a string, map,
 // slice, or array.
 //
@@ -161,57 +947,248 @@ func Len(seq interface{}, expected int) Comparison {
 // If collection is a Map, contains will succeed if item is a key in the map.
 // If collection is a slice or array, item is compared to each item in the
 // sequence using reflect.DeepEqual().
-func Contains(collection interface{}, item interface{}) Comparison {
-	return func() Result {
-		colValue := reflect.ValueOf(collection)
-		if !colValue.IsValid() {
-			return ResultFailure(fmt.Sprintf("nil does not contain items"))
+//
+// If any opts are given, items in a slice or array are compared using go-cmp
+// with opts, the same as DeepEqual, and the failure message includes a diff of
+// item and the closest element in the sequence. opts are not used for strings
+// or maps.
+func Contains(collection interface{}, item interface{}, opts ...cmp.Option) Comparison {
+	return func() (result Result) {
+		defer func() {
+			if panicmsg, handled := handleCmpPanic(recover()); handled {
+				result = ResultFailure(panicmsg)
+			}
+		}()
+		found, err := containsItem(collection, item, opts...)
+		switch {
+		case err != nil:
+			return ResultFailure(err.Error())
+		case found:
+			return ResultSuccess
+		case reflect.TypeOf(collection).Kind() == reflect.String:
+			return ResultFailure(fmt.Sprintf("string %q does not contain %q", collection, item))
 		}
 		msg := fmt.Sprintf("%v does not contain %v", collection, item)
-
-		itemValue := reflect.ValueOf(item)
-		switch colValue.Type().Kind() {
-		case reflect.String:
-			if itemValue.Type().Kind() != reflect.String {
-				return ResultFailure("string may only contain strings")
-			}
-			return toResult(
-				strings.Contains(colValue.String(), itemValue.String()),
-				fmt.Sprintf("string %q does not contain %q", collection, item))
-
-		case reflect.Map:
-			if itemValue.Type() != colValue.Type().Key() {
-				return ResultFailure(fmt.Sprintf(
-					"%v can not contain a %v key", colValue.Type(), itemValue.Type()))
+		if len(opts) > 0 {
+			colValue := reflect.ValueOf(collection)
+			switch colValue.Kind() {
+			case reflect.Slice, reflect.Array:
+				if index, diff := closestItem(colValue, item, opts); index >= 0 {
+					msg += fmt.Sprintf("\nclosest element is at index %d:\n%s", index, diff)
+				}
 			}
-			return toResult(colValue.MapIndex(itemValue).IsValid(), msg)
+		}
+		return ResultFailure(msg)
+	}
+}
+
+// closestItem returns the index of the element in the slice or array seq with
+// the shortest diff from item, and the diff. The index is -1 if seq is empty.
+func closestItem(seq reflect.Value, item interface{}, opts []cmp.Option) (int, string) {
+	index, closest := -1, ""
+	for i := 0; i < seq.Len(); i++ {
+		diff := cmp.Diff(seq.Index(i).Interface(), item, opts...)
+		if index < 0 || len(diff) < len(closest) {
+			index, closest = i, diff
+		}
+	}
+	return index, closest
+}
+
+// containsItem returns true if item is in collection. An error is returned if
+// collection is not a type which can contain items, or if item is not a valid
+// type of item for the collection.
+//
+// If opts are given, items in a slice or array are compared using go-cmp.
+func containsItem(collection interface{}, item interface{}, opts ...cmp.Option) (bool, error) {
+	colValue := reflect.ValueOf(collection)
+	if !colValue.IsValid() {
+		return false, fmt.Errorf("nil does not contain items")
+	}
+
+	itemValue := reflect.ValueOf(item)
+	if !itemValue.IsValid() {
+		return containsNil(colValue)
+	}
+	switch colValue.Type().Kind() {
+	case reflect.String:
+		if itemValue.Type().Kind() != reflect.String {
+			return false, fmt.Errorf("string may only contain strings")
+		}
+		return strings.Contains(colValue.String(), itemValue.String()), nil
+
+	case reflect.Map:
+		if itemValue.Type() != colValue.Type().Key() {
+			return false, fmt.Errorf(
+				"%v can not contain a %v key", colValue.Type(), itemValue.Type())
+		}
+		return colValue.MapIndex(itemValue).IsValid(), nil
+
+	case reflect.Slice, reflect.Array:
+		return indexOfItem(colValue, item, opts...) >= 0, nil
+	default:
+		return false, fmt.Errorf("type %T does not contain items", collection)
+	}
+}
+
+// containsNil returns true if the collection colValue contains a nil key, for
+// a map, or a nil element, for a slice or array.
+func containsNil(colValue reflect.Value) (bool, error) {
+	switch colValue.Kind() {
+	case reflect.String:
+		return false, fmt.Errorf("string may only contain strings")
+	case reflect.Map:
+		keyType := colValue.Type().Key()
+		if !isNillable(keyType) {
+			return false, fmt.Errorf("%v can not contain a nil key", colValue.Type())
+		}
+		return colValue.MapIndex(reflect.Zero(keyType)).IsValid(), nil
+	case reflect.Slice, reflect.Array:
+		elemType := colValue.Type().Elem()
+		if !isNillable(elemType) {
+			return false, fmt.Errorf("%v can not contain a nil value", colValue.Type())
+		}
+		return indexOfItem(colValue, reflect.Zero(elemType).Interface()) >= 0, nil
+	}
+	return false, fmt.Errorf("type %v does not contain items", colValue.Type())
+}
+
+// indexOfItem returns the index of the first element in the slice or array seq
+// which is equal to item, or -1 if no element is equal. Elements are compared
+// using reflect.DeepEqual(), or go-cmp if any opts are given.
+func indexOfItem(seq reflect.Value, item interface{}, opts ...cmp.Option) int {
+	for i := 0; i < seq.Len(); i++ {
+		elem := seq.Index(i).Interface()
+		if len(opts) == 0 && reflect.DeepEqual(elem, item) ||
+			len(opts) > 0 && cmp.Equal(elem, item, opts...) {
+			return i
+		}
+	}
+	return -1
+}
 
+// NotContains succeeds if item is not in collection. Collection may be a
+// string, map, slice, or array, and item is compared the same way as Contains.
+//
+// Unlike Contains, which fails when collection is nil, NotContains succeeds
+// when collection is nil because nil does not contain any items.
+func NotContains(collection interface{}, item interface{}) Comparison {
+	return func() Result {
+		if collection == nil {
+			return ResultSuccess
+		}
+		found, err := containsItem(collection, item)
+		switch {
+		case err != nil:
+			return ResultFailure(err.Error())
+		case !found:
+			return ResultSuccess
+		}
+
+		colValue := reflect.ValueOf(collection)
+		switch colValue.Kind() {
+		case reflect.String:
+			return ResultFailure(fmt.Sprintf("string %q unexpectedly contains %q", collection, item))
 		case reflect.Slice, reflect.Array:
-			for i := 0; i < colValue.Len(); i++ {
-				if reflect.DeepEqual(colValue.Index(i).Interface(), item) {
-					return ResultSuccess
-				}
+			target := item
+			if item == nil {
+				target = reflect.Zero(colValue.Type().Elem()).Interface()
 			}
-			return ResultFailure(msg)
-		default:
-			return ResultFailure(fmt.Sprintf("type %T does not contain items", collection))
+			return ResultFailure(fmt.Sprintf("%v unexpectedly contains %v at index %d",
+				collection, item, indexOfItem(colValue, target)))
 		}
+		return ResultFailure(fmt.Sprintf("%v unexpectedly contains %v", collection, item))
 	}
 }
 
-// Panics succeeds if f() panics.
+// Panics succeeds if f() panics. A panic with a nil value is also considered
+// a panic.
 func Panics(f func()) Comparison {
-	return func() (result Result) {
-		defer func() {
-			if err := recover(); err != nil {
-				result = ResultSuccess
-			}
-		}()
-		f()
+	return func() Result {
+		if _, panicked := recoverPanic(f); panicked {
+			return ResultSuccess
+		}
 		return ResultFailure("did not panic")
 	}
 }
 
+// PanicsAndReturn returns a Comparison which succeeds if f() panics, and a
+// pointer to the recovered value. The value is set when the Comparison is run,
+// so that it can be inspected with other comparisons.
+//
+// Example:
+//   panics, recovered := cmp.PanicsAndReturn(func() { parse(input) })
+//   assert.Assert(t, panics)
+//   assert.Assert(t, cmp.ErrorContains((*recovered).(error), "unexpected token"))
+func PanicsAndReturn(f func()) (Comparison, *interface{}) {
+	recovered := new(interface{})
+	return func() Result {
+		value, panicked := recoverPanic(f)
+		if !panicked {
+			return ResultFailure("did not panic")
+		}
+		*recovered = value
+		return ResultSuccess
+	}, recovered
+}
+
+// PanicsWithValue succeeds if f() panics, and the recovered value is equal to
+// expected. Values are compared using reflect.DeepEqual().
+func PanicsWithValue(expected interface{}, f func()) Comparison {
+	return func() Result {
+		recovered, panicked := recoverPanic(f)
+		switch {
+		case !panicked:
+			return ResultFailure("did not panic")
+		case !reflect.DeepEqual(recovered, expected):
+			return ResultFailure(fmt.Sprintf(
+				"expected panic with %v (%T), got panic with %v (%T)",
+				expected, expected, recovered, recovered))
+		}
+		return ResultSuccess
+	}
+}
+
+// PanicsWithMessage succeeds if f() panics, and the recovered value contains
+// substring. If the recovered value is an error or a fmt.Stringer the
+// message is the value of Error() or String(), otherwise the value is
+// formatted using fmt.Sprint.
+func PanicsWithMessage(substring string, f func()) Comparison {
+	return func() Result {
+		recovered, panicked := recoverPanic(f)
+		if !panicked {
+			return ResultFailure("did not panic")
+		}
+		msg := panicMessage(recovered)
+		return toResult(strings.Contains(msg, substring), fmt.Sprintf(
+			"expected panic message to contain %q, got %q", substring, msg))
+	}
+}
+
+// recoverPanic calls f and returns the value recovered from a panic, and true
+// if f panicked. A panic with a nil value is still reported as a panic.
+func recoverPanic(f func()) (recovered interface{}, panicked bool) {
+	panicked = true
+	defer func() {
+		if panicked {
+			recovered = recover()
+		}
+	}()
+	f()
+	panicked = false
+	return nil, panicked
+}
+
+func panicMessage(recovered interface{}) string {
+	switch value := recovered.(type) {
+	case error:
+		return value.Error()
+	case fmt.Stringer:
+		return value.String()
+	}
+	return fmt.Sprint(recovered)
+}
+
 // Error succeeds if err is a non-nil error, and the error message equals the
 // expected message.
 func Error(err error, message string) Comparison {
@@ -242,6 +1219,27 @@ func ErrorContains(err error, substring string) Comparison {
 	}
 }
 
+// ErrorContainsAll succeeds if err is a non-nil error, and the error message
+// contains all of the expected substrings.
+func ErrorContainsAll(err error, substrings ...string) Comparison {
+	return func() Result {
+		if err == nil {
+			return ResultFailure("expected an error, got nil")
+		}
+		var missing []string
+		for _, substring := range substrings {
+			if !strings.Contains(err.Error(), substring) {
+				missing = append(missing, fmt.Sprintf("%q", substring))
+			}
+		}
+		if len(missing) == 0 {
+			return ResultSuccess
+		}
+		return ResultFailure(fmt.Sprintf("expected error to contain %s, got %s",
+			strings.Join(missing, ", "), formatErrorMessage(err)))
+	}
+}
+
 type causer interface {
 	Cause() error
 }
@@ -254,6 +1252,30 @@ func formatErrorMessage(err error) string {
 	return fmt.Sprintf("%q", err)
 }
 
+// NilError succeeds if err is nil. If err wraps multiple errors, for example an
+// error from errors.Join, the failure message includes each error on a
+// separate line. Otherwise the error is formatted using %+v.
+func NilError(err error) Comparison {
+	return func() Result {
+		if err == nil {
+			return ResultSuccess
+		}
+		// Handle nil structs which implement error as a nil error
+		if value := reflect.ValueOf(err); value.Kind() == reflect.Ptr && value.IsNil() {
+			return ResultSuccess
+		}
+		if multi, ok := format.MultiError(err); ok {
+			return ResultFailure("error is not nil: " + multi)
+		}
+		return ResultFailure(fmt.Sprintf("error is not nil: %+v", err))
+	}
+}
+
+// NoError succeeds if err is nil. NoError is an alias for NilError.
+func NoError(err error) Comparison {
+	return NilError(err)
+}
+
 // Nil succeeds if obj is a nil interface, pointer, or function.
 //
 // Use NilError() for comparing errors. Use Len(obj, 0) for comparing slices,
@@ -265,6 +1287,20 @@ func Nil(obj interface{}) Comparison {
 	return isNil(obj, msgFunc)
 }
 
+// Zero succeeds if obj is the zero value for its type. A nil interface is also
+// considered zero. Values are compared using reflect.DeepEqual(), so a struct
+// is only zero when all its fields are zero, and an empty slice is not zero.
+func Zero(obj interface{}) Comparison {
+	return func() Result {
+		if obj == nil {
+			return ResultSuccess
+		}
+		zero := reflect.Zero(reflect.TypeOf(obj)).Interface()
+		return toResult(reflect.DeepEqual(obj, zero), fmt.Sprintf(
+			"%+v (type %T) is not the zero value for its type", obj, obj))
+	}
+}
+
 func isNil(obj interface{}, msgFunc func(reflect.Value) string) Comparison {
 	return func() Result {
 		if obj == nil {
@@ -283,7 +1319,10 @@ func isNil(obj interface{}, msgFunc func(reflect.Value) string) Comparison {
 	}
 }
 
-// ErrorType succeeds if err is not nil and is of the expected type.
+// ErrorType succeeds if err is not nil and is of the expected type. Like
+// errors.As, the error and each error it wraps (using an Unwrap() error
+// method) are checked against a type or interface, and ErrorType succeeds if
+// any of them match.
 //
 // Expected can be one of:
 //   func(error) bool
@@ -338,9 +1377,10 @@ func cmpErrorTypeEqualType(err error, expectedType reflect.Type) Result {
 	if err == nil {
 		return ResultFailure(fmt.Sprintf("error is nil, not %s", expectedType))
 	}
-	errValue := reflect.ValueOf(err)
-	if errValue.Type() == expectedType {
-		return ResultSuccess
+	for _, wrapped := range errorChain(err) {
+		if reflect.TypeOf(wrapped) == expectedType {
+			return ResultSuccess
+		}
 	}
 	return ResultFailure(fmt.Sprintf("error is %s (%T), not %s", err, err, expectedType))
 }
@@ -349,13 +1389,33 @@ func cmpErrorTypeImplementsType(err error, expectedType reflect.Type) Result {
 	if err == nil {
 		return ResultFailure(fmt.Sprintf("error is nil, not %s", expectedType))
 	}
-	errValue := reflect.ValueOf(err)
-	if errValue.Type().Implements(expectedType) {
-		return ResultSuccess
+	for _, wrapped := range errorChain(err) {
+		if reflect.TypeOf(wrapped).Implements(expectedType) {
+			return ResultSuccess
+		}
 	}
 	return ResultFailure(fmt.Sprintf("error is %s (%T), not %s", err, err, expectedType))
 }
 
+type wrapper interface {
+	Unwrap() error
+}
+
+// errorChain returns err followed by each error it wraps, following the
+// Unwrap() method in the same way as errors.As.
+func errorChain(err error) []error {
+	var chain []error
+	for err != nil {
+		chain = append(chain, err)
+		wrapped, ok := err.(wrapper)
+		if !ok {
+			break
+		}
+		err = wrapped.Unwrap()
+	}
+	return chain
+}
+
 func isPtrToInterface(typ reflect.Type) bool {
 	return typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Interface
 }
@@ -363,3 +1423,216 @@ func isPtrToInterface(typ reflect.Type) bool {
 func isPtrToStruct(typ reflect.Type) bool {
 	return typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Struct
 }
+
+// Match succeeds if predicate(value) returns true. description should describe
+// the condition checked by predicate, and is included in the failure message.
+// If predicate panics the comparison fails with the panic value.
+//
+// Match can be used for a condition which does not have a comparison in this
+// package.
+//
+// Example:
+//   assert.Assert(t, cmp.Match(port, func(v interface{}) bool {
+//       return v.(int)%2 == 0
+//   }, "an even port number"))
+func Match(value interface{}, predicate func(interface{}) bool, description string) Comparison {
+	return func() Result {
+		var ok bool
+		if recovered, panicked := recoverPanic(func() { ok = predicate(value) }); panicked {
+			return ResultFailure(fmt.Sprintf(
+				"predicate panicked for value %v: %v", value, recovered))
+		}
+		return toResult(ok, fmt.Sprintf("value %v did not satisfy: %s", value, description))
+	}
+}
+
+// MatchString succeeds if predicate(s) returns true. See Match for details.
+func MatchString(s string, predicate func(string) bool, description string) Comparison {
+	return func() Result {
+		var ok bool
+		if recovered, panicked := recoverPanic(func() { ok = predicate(s) }); panicked {
+			return ResultFailure(fmt.Sprintf(
+				"predicate panicked for value %q: %v", s, recovered))
+		}
+		return toResult(ok, fmt.Sprintf("value %q did not satisfy: %s", s, description))
+	}
+}
+
+// Not succeeds if the comparison c fails, and fails if c succeeds. Not does
+// not recover from panics, so a comparison which panics will continue to panic.
+//
+// A warning from cmp.ResultWarning is a success, so Not fails if c returns a
+// warning. The failure message includes the message of the warning.
+//
+// Example:
+//   assert.Assert(t, cmp.Not(cmp.Contains(items, "deleted")))
+func Not(c Comparison) Comparison {
+	return func() Result {
+		result := c()
+		if !result.Success() {
+			return ResultSuccess
+		}
+		var warning string
+		if isWarning(result) {
+			warning = failureMessage(result, nil)
+		}
+		return ResultFailureTemplate(`expected comparison
+			{{- with callArg 0 }} {{ formatNode . }}
+			{{- else }}{{ with .Data.name }} {{ . }}{{ end }}{{ end }} to fail, but it succeeded
+			{{- with .Data.warning }} with a warning: {{ . }}{{ end }}`,
+			map[string]interface{}{"name": comparisonName(c), "warning": warning})
+	}
+}
+
+// And succeeds if all of the comparisons succeed. The comparisons are run in
+// order, and And returns the Result of the first comparison which fails. Any
+// comparisons after the first failure are not run. If all of the comparisons
+// succeed, And returns the first warning from cmp.ResultWarning, if there was
+// one.
+func And(comparisons ...Comparison) Comparison {
+	return func() Result {
+		var warning Result
+		for _, c := range comparisons {
+			result := c()
+			switch {
+			case !result.Success():
+				return ResultFailure(failureMessage(result, nil))
+			case warning == nil && isWarning(result):
+				warning = ResultWarning(failureMessage(result, nil))
+			}
+		}
+		if warning != nil {
+			return warning
+		}
+		return ResultSuccess
+	}
+}
+
+// Or succeeds if any of the comparisons succeed. The comparisons are run in
+// order until one succeeds. If the comparison which succeeded returned a
+// warning, Or returns the warning. If none of the comparisons succeed the
+// failure message includes the failure message from each comparison.
+func Or(comparisons ...Comparison) Comparison {
+	return func() Result {
+		if len(comparisons) == 0 {
+			return ResultFailure("no comparisons to run")
+		}
+		buf := new(bytes.Buffer)
+		buf.WriteString("none of the comparisons succeeded:")
+		for i, c := range comparisons {
+			result := c()
+			switch {
+			case isWarning(result):
+				return ResultWarning(failureMessage(result, nil))
+			case result.Success():
+				return ResultSuccess
+			}
+			fmt.Fprintf(buf, "\n  %d:%s", i+1, indentMessage(failureMessage(result, nil)))
+		}
+		return ResultFailure(buf.String())
+	}
+}
+
+// indentMessage formats a failure message for a list of failures. A message
+// with a single line is separated from the list item by a space, and each line
+// of a multi-line message is indented on a new line.
+func indentMessage(msg string) string {
+	msg = strings.Trim(msg, "\n")
+	if strings.Contains(msg, "\n") {
+		return "\n    " + strings.Replace(msg, "\n", "\n    ", -1)
+	}
+	return " " + msg
+}
+
+// WithLabel returns a Comparison which prefixes the failure message of c with
+// label. A warning from c is also prefixed with label. The Result is unchanged
+// when c succeeds without a warning.
+//
+// Example:
+//   for _, c := range []cmp.Comparison{
+//       cmp.WithLabel("name", cmp.Equal(user.Name, "Alice")),
+//       cmp.WithLabel("roles", cmp.Contains(user.Roles, "admin")),
+//   } {
+//       assert.Check(t, c)
+//   }
+func WithLabel(label string, c Comparison) Comparison {
+	return func() Result {
+		result := c()
+		if result.Success() && !isWarning(result) {
+			return result
+		}
+		return resultWithMessage(result, label+": "+failureMessage(result, nil))
+	}
+}
+
+// Timed returns a Comparison which measures how long c takes to run. If c fails
+// or returns a warning, the duration is appended to the message, for example
+// "(comparison took 1.2s)". The Result is unchanged when c succeeds without a
+// warning. Timed does not recover from panics.
+func Timed(c Comparison) Comparison {
+	return func() Result {
+		start := time.Now()
+		result := c()
+		elapsed := time.Since(start)
+		if result.Success() && !isWarning(result) {
+			return result
+		}
+		return appendMessage(result, " ", fmt.Sprintf("(comparison took %s)", elapsed))
+	}
+}
+
+// Annotated returns a Comparison which appends an annotation to the failure
+// message of c, or to the message of a warning from c. The annotation is
+// formatted with fmt.Sprintf(format, args...) only if c fails or returns a
+// warning. The Result is unchanged when c succeeds without a warning.
+//
+// Example:
+//   for i, record := range records {
+//       assert.Check(t, cmp.Annotated(cmp.Equal(record.Status, "done"),
+//           "while processing record %d", i))
+//   }
+func Annotated(c Comparison, format string, args ...interface{}) Comparison {
+	return func() Result {
+		result := c()
+		if result.Success() && !isWarning(result) {
+			return result
+		}
+		return appendMessage(result, ": ", fmt.Sprintf(format, args...))
+	}
+}
+
+// appendMessage returns a Result with suffix appended to the message of result,
+// which is a failure or a warning. sep is added between the message and the suffix, unless
+// the message ends with a newline.
+//
+// The args of the wrapping comparison are not the args of the wrapped
+// Comparison, so the message of result is rendered without args.
+func appendMessage(result Result, sep, suffix string) Result {
+	msg := failureMessage(result, nil)
+	if !strings.HasSuffix(msg, "\n") {
+		msg += sep
+	}
+	return resultWithMessage(result, msg+suffix)
+}
+
+// comparisonName returns the name of a Comparison function from this package,
+// or an empty string if c was defined elsewhere.
+func comparisonName(c Comparison) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(c).Pointer())
+	if fn == nil {
+		return ""
+	}
+	const pkgPath = "gotest.tools/v3/assert/cmp."
+	name := fn.Name()
+	if !strings.HasPrefix(name, pkgPath) {
+		return ""
+	}
+	if file, _ := fn.FileLine(fn.Entry()); strings.HasSuffix(file, "_test.go") {
+		return ""
+	}
+	name = strings.TrimPrefix(name, pkgPath)
+	if i := strings.Index(name, "."); i > 0 {
+		name = name[:i]
+	}
+	return "cmp." + name
+}
@@ -0,0 +1,80 @@
+//go:build go1.13
+// +build go1.13
+
+package cmp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+)
+
+// ErrorIs succeeds if errors.Is(err, expected) returns true. Unlike Error and
+// ErrorContains, ErrorIs will match an error which has been wrapped using
+// fmt.Errorf with %w.
+//
+// ErrorIs succeeds if both err and expected are nil.
+func ErrorIs(err error, expected error) Comparison {
+	return func() Result {
+		switch {
+		case errors.Is(err, expected):
+			return ResultSuccess
+		case err == nil:
+			return ResultFailure(fmt.Sprintf("expected error %q (%T), got nil", expected, expected))
+		case expected == nil:
+			return ResultFailure(fmt.Sprintf("expected nil error, got %+v (%T)", err, err))
+		}
+		return ResultFailure(fmt.Sprintf(
+			"error is %+v (%T), not %q (%T)", err, err, expected, expected))
+	}
+}
+
+// ErrorMatches succeeds if errors.As(err, typePtr) returns true, and the
+// message of the error assigned to typePtr matches the regular expression
+// pattern. typePtr must be a non-nil pointer to an interface type or to a type
+// which implements error, the same as the target argument of errors.As.
+//
+// Example:
+//   var pathErr *os.PathError
+//   assert.Assert(t, cmp.ErrorMatches(err, &pathErr, `^open .*config.yaml:`))
+func ErrorMatches(err error, typePtr interface{}, pattern string) Comparison {
+	return func() Result {
+		if err == nil {
+			return ResultFailure("expected an error, got nil")
+		}
+		if msg := checkErrorTarget(typePtr); msg != "" {
+			return ResultFailure(msg)
+		}
+		re, compileErr := regexp.Compile(pattern)
+		if compileErr != nil {
+			return ResultFailure(compileErr.Error())
+		}
+
+		target := reflect.ValueOf(typePtr)
+		if !errors.As(err, typePtr) {
+			return ResultFailure(fmt.Sprintf(
+				"error is %+v (%T), not %s", err, err, target.Type().Elem()))
+		}
+		matched := target.Elem().Interface().(error)
+		return toResult(re.MatchString(matched.Error()), fmt.Sprintf(
+			"error message %q (%T) does not match regexp %q", matched.Error(), matched, pattern))
+	}
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// checkErrorTarget returns a failure message if typePtr is not a valid target
+// for errors.As, instead of letting errors.As panic.
+func checkErrorTarget(typePtr interface{}) string {
+	value := reflect.ValueOf(typePtr)
+	if !value.IsValid() || value.Kind() != reflect.Ptr || value.IsNil() {
+		return fmt.Sprintf("typePtr must be a non-nil pointer, got %T", typePtr)
+	}
+	elem := value.Type().Elem()
+	if elem.Kind() != reflect.Interface && !elem.Implements(errorType) {
+		return fmt.Sprintf("typePtr must be a pointer to an interface or to a "+
+			"type which implements error, got %T", typePtr)
+	}
+	return ""
+}
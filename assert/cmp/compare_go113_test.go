@@ -0,0 +1,118 @@
+//go:build go1.13
+// +build go1.13
+
+package cmp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestErrorIs(t *testing.T) {
+	t.Run("equal errors", func(t *testing.T) {
+		result := ErrorIs(io.EOF, io.EOF)()
+		assertSuccess(t, result)
+	})
+
+	t.Run("wrapped error", func(t *testing.T) {
+		err := fmt.Errorf("reading header: %w", io.EOF)
+		result := ErrorIs(err, io.EOF)()
+		assertSuccess(t, result)
+	})
+
+	t.Run("both nil", func(t *testing.T) {
+		result := ErrorIs(nil, nil)()
+		assertSuccess(t, result)
+	})
+
+	t.Run("nil error", func(t *testing.T) {
+		result := ErrorIs(nil, io.EOF)()
+		assertFailure(t, result, `expected error "EOF" (*errors.errorString), got nil`)
+	})
+
+	t.Run("nil expected", func(t *testing.T) {
+		result := ErrorIs(io.EOF, nil)()
+		assertFailure(t, result, `expected nil error, got EOF (*errors.errorString)`)
+	})
+
+	t.Run("different error", func(t *testing.T) {
+		err := fmt.Errorf("opening config: %w", os.ErrNotExist)
+		result := ErrorIs(err, os.ErrPermission)()
+		assertFailure(t, result, `error is opening config: file does not exist `+
+			`(*fmt.wrapError), not "permission denied" (*errors.errorString)`)
+	})
+
+	t.Run("same message but a different error", func(t *testing.T) {
+		result := ErrorIs(errors.New("EOF"), io.EOF)()
+		assertFailure(t, result,
+			`error is EOF (*errors.errorString), not "EOF" (*errors.errorString)`)
+	})
+}
+
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("status %d", e.code)
+}
+
+func TestErrorMatches(t *testing.T) {
+	statusErr := &statusError{code: 404}
+	err := fmt.Errorf("fetching config: %w", statusErr)
+
+	t.Run("success", func(t *testing.T) {
+		var target *statusError
+		assertSuccess(t, ErrorMatches(err, &target, `^status 4\d\d$`)())
+		if target != statusErr {
+			t.Errorf("expected target to be set to the wrapped error")
+		}
+	})
+
+	t.Run("interface target", func(t *testing.T) {
+		var target interface{ Timeout() bool }
+		result := ErrorMatches(err, &target, "timeout")()
+		assertFailure(t, result, "error is fetching config: status 404 "+
+			"(*fmt.wrapError), not interface { Timeout() bool }")
+	})
+
+	t.Run("nil error", func(t *testing.T) {
+		var target *statusError
+		assertFailure(t, ErrorMatches(nil, &target, ".*")(), "expected an error, got nil")
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		var target *stubError
+		result := ErrorMatches(err, &target, ".*")()
+		assertFailure(t, result, "error is fetching config: status 404 "+
+			"(*fmt.wrapError), not *cmp.stubError")
+	})
+
+	t.Run("message does not match", func(t *testing.T) {
+		var target *statusError
+		result := ErrorMatches(err, &target, "^status 5")()
+		assertFailure(t, result,
+			`error message "status 404" (*cmp.statusError) does not match regexp "^status 5"`)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		var target *statusError
+		result := ErrorMatches(err, &target, "(")()
+		assertFailure(t, result, "error parsing regexp: missing closing ): `(`")
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		var target *statusError
+		assertFailure(t, ErrorMatches(err, target, ".*")(),
+			"typePtr must be a non-nil pointer, got *cmp.statusError")
+		assertFailure(t, ErrorMatches(err, statusError{}, ".*")(),
+			"typePtr must be a non-nil pointer, got cmp.statusError")
+
+		var notError string
+		assertFailure(t, ErrorMatches(err, &notError, ".*")(),
+			"typePtr must be a pointer to an interface or to a type which implements error, got *string")
+	})
+}
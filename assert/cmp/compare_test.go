@@ -4,15 +4,40 @@ import (
 	"fmt"
 	"go/ast"
 	"io"
+	"os"
 	"reflect"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
+	"gotest.tools/v3/internal/format"
 )
 
+func TestMain(m *testing.M) {
+	// Many tests compare diffs without colors, so colors must not be enabled
+	// by the environment of the test process.
+	os.Unsetenv(format.ColorEnvVar)
+	os.Exit(m.Run())
+}
+
+// patchEnv sets the environment variable key to value, and returns a function
+// which restores the previous value.
+func patchEnv(key, value string) func() {
+	orig, exists := os.LookupEnv(key)
+	os.Setenv(key, value)
+	return func() {
+		if !exists {
+			os.Unsetenv(key)
+			return
+		}
+		os.Setenv(key, orig)
+	}
+}
+
 func TestDeepEqual(t *testing.T) {
 	t.Run("failure", func(t *testing.T) {
 		result := DeepEqual([]string{"a", "b"}, []string{"b", "a"})()
@@ -32,6 +57,98 @@ func TestDeepEqual(t *testing.T) {
 		actual := DeepEqual([]string{"a"}, []string{"a"})()
 		assertSuccess(t, actual)
 	})
+
+	t.Run("panic from a comparer is not handled", func(t *testing.T) {
+		const msg = "the comparer does not support an unexported field"
+		defer func() {
+			if r := recover(); r != msg {
+				t.Errorf("expected panic to be re-raised, got %v", r)
+			}
+		}()
+		panics := cmp.Comparer(func(x, y int) bool { panic(msg) })
+		DeepEqual(1, 2, panics)()
+	})
+}
+
+func TestDeepEqualBounded(t *testing.T) {
+	var x, y []int
+	for i := 0; i < 1000; i++ {
+		x = append(x, i)
+		if i%10 == 0 {
+			y = append(y, -i)
+		} else {
+			y = append(y, i)
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		assertSuccess(t, DeepEqualBounded(x, x, 3)())
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		result := DeepEqualBounded(x, y, 3)()
+		args := []ast.Expr{&ast.Ident{Name: "x"}, &ast.Ident{Name: "y"}}
+		msg := result.(templatedResult).FailureMessage(args)
+		if !strings.HasSuffix(msg, "\n... (96 more differences omitted, 99 total)\n") {
+			t.Errorf("expected message to end with the number of omitted differences, got\n%s", msg)
+		}
+		if strings.Contains(msg, "-40,") {
+			t.Errorf("expected the 4th difference to be omitted, got\n%s", msg)
+		}
+		if !strings.Contains(msg, "-30,") {
+			t.Errorf("expected the 3rd difference to be included, got\n%s", msg)
+		}
+	})
+
+	t.Run("not truncated", func(t *testing.T) {
+		result := DeepEqualBounded(x[:25], y[:25], 3)()
+		msg := result.(templatedResult).FailureMessage(nil)
+		if strings.Contains(msg, "omitted") {
+			t.Errorf("expected full diff, got\n%s", msg)
+		}
+	})
+
+	t.Run("values are compared once", func(t *testing.T) {
+		type pair struct{ A, B int }
+		var calls int
+		counting := cmp.Comparer(func(x, y int) bool {
+			calls++
+			return x == y
+		})
+		x, y := pair{A: 1, B: 2}, pair{A: 1, B: 3}
+		cmp.Equal(x, y, counting)
+		expected := calls
+
+		calls = 0
+		if DeepEqualBounded(x, y, 1, counting)().Success() {
+			t.Fatalf("expected failure")
+		}
+		if calls != expected {
+			t.Errorf("expected the comparer to be called %d times, got %d", expected, calls)
+		}
+	})
+
+	t.Run("invalid maxDiffs", func(t *testing.T) {
+		assertFailure(t, DeepEqualBounded(x, y, 0)(), "invalid maxDiffs 0, maxDiffs must be at least 1")
+		assertFailure(t, DeepEqualBounded(x, x, -1)(), "invalid maxDiffs -1, maxDiffs must be at least 1")
+	})
+
+	t.Run("unexported fields", func(t *testing.T) {
+		result := DeepEqualBounded(Stub{}, Stub{unx: 1}, 1)()
+		assertFailureHasPrefix(t, result, `cannot handle unexported field: {cmp.Stub}.unx`)
+	})
+}
+
+func TestTruncateDiff(t *testing.T) {
+	diff := "  []int{\n-  0,\n+  1,\n   2,\n-  3,\n   4,\n+  5,\n  }\n"
+	expected := "  []int{\n-  0,\n+  1,\n   2,\n-  3,\n   4,\n" +
+		"... (1 more differences omitted, 3 total)\n"
+	if actual := truncateDiff(diff, 2); actual != expected {
+		t.Errorf("expected\n%q\ngot\n%q", expected, actual)
+	}
+	if actual := truncateDiff(diff, 3); actual != diff {
+		t.Errorf("expected diff to be unchanged, got\n%q", actual)
+	}
 }
 
 type Stub struct {
@@ -41,6 +158,387 @@ type Stub struct {
 func TestDeepEqualWithUnexported(t *testing.T) {
 	result := DeepEqual(Stub{}, Stub{unx: 1})()
 	assertFailureHasPrefix(t, result, `cannot handle unexported field: {cmp.Stub}.unx`)
+
+	msg := result.(StringResult).FailureMessage()
+	expected := "use cmpopts.IgnoreUnexported(cmp.Stub{}) to ignore the unexported " +
+		"fields of cmp.Stub, or a cmp.Comparer to compare values of cmp.Stub"
+	if !strings.HasSuffix(msg, "\n"+expected) {
+		t.Errorf("expected message to end with advice %q, got %q", expected, msg)
+	}
+}
+
+func TestHandleCmpPanic(t *testing.T) {
+	const help = "consider using a custom Comparer"
+	var testcases = []struct {
+		name     string
+		panicmsg interface{}
+		expected string
+	}{
+		{
+			name:     "field on root struct",
+			panicmsg: "cannot handle unexported field: {pkg.T}.unx\n" + help,
+			expected: "use cmpopts.IgnoreUnexported(pkg.T{}) to ignore the unexported " +
+				"fields of pkg.T, or a cmp.Comparer to compare values of pkg.T",
+		},
+		{
+			name:     "nested field",
+			panicmsg: "cannot handle unexported field: {pkg.T}.Inner.unx\n" + help,
+			expected: "use cmpopts.IgnoreUnexported to ignore the unexported fields, " +
+				"or a cmp.Comparer to compare values of the struct",
+		},
+		{
+			name: "with qualified type",
+			panicmsg: "cannot handle unexported field at {pkg.T}.Inner.unx:\n" +
+				"\t\"example.com/other\".Inner\n" + help,
+			expected: "use cmpopts.IgnoreUnexported(other.Inner{}) to ignore the unexported " +
+				"fields of other.Inner, or a cmp.Comparer to compare values of other.Inner",
+		},
+		{
+			name:     "error value",
+			panicmsg: errors.New("cannot handle unexported field: {pkg.T}.unx"),
+			expected: "use cmpopts.IgnoreUnexported(pkg.T{}) to ignore the unexported " +
+				"fields of pkg.T, or a cmp.Comparer to compare values of pkg.T",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			msg, handled := handleCmpPanic(testcase.panicmsg)
+			if !handled {
+				t.Fatalf("expected panic to be handled")
+			}
+			if !strings.HasSuffix(msg, "\n"+testcase.expected) {
+				t.Errorf("expected message to end with %q, got %q", testcase.expected, msg)
+			}
+		})
+	}
+
+	t.Run("other panics are not handled", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "something else" {
+				t.Errorf("expected panic to be re-raised, got %v", r)
+			}
+		}()
+		handleCmpPanic("something else")
+	})
+}
+
+func TestRegisterPanicHandler(t *testing.T) {
+	defer func(orig []func(interface{}) (string, bool)) {
+		panicHandlers.handlers = orig
+	}(panicHandlers.handlers)
+
+	RegisterPanicHandler(func(r interface{}) (string, bool) {
+		if msg, ok := r.(string); ok && strings.HasPrefix(msg, "benign:") {
+			return "comparer failed: " + msg, true
+		}
+		return "", false
+	})
+
+	panicky := func(msg string) cmp.Option {
+		return cmp.Comparer(func(x, y int) bool { panic(msg) })
+	}
+
+	t.Run("handled by registered handler", func(t *testing.T) {
+		result := DeepEqual(1, 2, panicky("benign: oops"))()
+		assertFailure(t, result, "comparer failed: benign: oops")
+	})
+
+	t.Run("falls through to default handling", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "something else" {
+				t.Errorf("expected panic to be re-raised, got %v", r)
+			}
+		}()
+		DeepEqual(1, 2, panicky("something else"))()
+	})
+}
+
+type metadata struct {
+	ID        string
+	CreatedAt int64
+}
+
+type record struct {
+	Name     string
+	Metadata metadata
+	Parent   *record
+}
+
+type server struct {
+	Name string
+	Port int
+}
+
+type config struct {
+	Servers []server
+	Labels  map[string]string
+}
+
+type document struct {
+	Body  string
+	Raw   []byte
+	Title string
+}
+
+func TestDeepEqualWithMaxValueWidth(t *testing.T) {
+	long := func(middle string) string {
+		return strings.Repeat("a", 100) + middle + strings.Repeat("b", 100)
+	}
+
+	t.Run("equal values", func(t *testing.T) {
+		x := document{Body: long("X"), Raw: []byte(long("X"))}
+		y := document{Body: long("X"), Raw: []byte(long("X"))}
+		assertSuccess(t, DeepEqual(x, y, WithMaxValueWidth(10))())
+	})
+
+	t.Run("truncated around the difference", func(t *testing.T) {
+		x := document{Body: long("X"), Raw: []byte("zzzzzzzzzzzz1"), Title: "short"}
+		y := document{Body: long("Y"), Raw: []byte("zzzzzzzzzzzz2"), Title: "other"}
+		result := DeepEqual(x, y, WithMaxValueWidth(10))()
+		if result.Success() {
+			t.Fatalf("expected failure")
+		}
+		msg := failureMessage(result, nil)
+		for _, expected := range []string{
+			`"…aaaaaXbbbb…"`, `"…aaaaaYbbbb…"`,
+			`"…zzzzz1"`, `"…zzzzz2"`,
+			`"short"`, `"other"`,
+		} {
+			if !strings.Contains(msg, expected) {
+				t.Errorf("expected diff to contain %s, got\n%s", expected, msg)
+			}
+		}
+		if strings.Contains(msg, long("X")) {
+			t.Errorf("expected long values to be truncated, got\n%s", msg)
+		}
+	})
+
+	t.Run("different lengths", func(t *testing.T) {
+		x := document{Body: strings.Repeat("a", 20)}
+		y := document{Body: strings.Repeat("a", 21)}
+		result := DeepEqual(x, y, WithMaxValueWidth(6))()
+		msg := failureMessage(result, nil)
+		if !strings.Contains(msg, `"…aaa"`) || !strings.Contains(msg, `"…aaaa"`) {
+			t.Errorf("expected truncated values, got\n%s", msg)
+		}
+	})
+}
+
+func TestFirstDiffPath(t *testing.T) {
+	x := config{Servers: []server{{"alpha", 80}, {"beta", 81}, {"gamma", 82}}}
+
+	t.Run("success", func(t *testing.T) {
+		y := config{Servers: []server{{"alpha", 80}, {"beta", 81}, {"gamma", 82}}}
+		assertSuccess(t, FirstDiffPath(x, y)())
+	})
+
+	t.Run("first of several differences", func(t *testing.T) {
+		y := config{Servers: []server{{"alpha", 80}, {"beta", 91}, {"delta", 92}}}
+		assertFailure(t, FirstDiffPath(x, y)(), "{cmp.config}.Servers[1].Port: 81 != 91")
+	})
+
+	t.Run("missing element", func(t *testing.T) {
+		y := config{Servers: []server{{"alpha", 80}, {"beta", 81}}}
+		assertFailure(t, FirstDiffPath(x, y)(),
+			`{cmp.config}.Servers[2->?]: cmp.server{Name:"gamma", Port:82} != <missing>`)
+	})
+
+	t.Run("map value", func(t *testing.T) {
+		x := config{Labels: map[string]string{"env": "prod"}}
+		y := config{Labels: map[string]string{"env": "dev"}}
+		assertFailure(t, FirstDiffPath(x, y)(), `{cmp.config}.Labels["env"]: "prod" != "dev"`)
+	})
+
+	t.Run("with options", func(t *testing.T) {
+		y := config{Servers: []server{{"alpha", 80}, {"beta", 81}, {"gamma", 82}}, Labels: map[string]string{}}
+		assertFailure(t, FirstDiffPath(x, y)(),
+			`{cmp.config}.Labels: map[string]string(nil) != map[string]string{}`)
+		assertSuccess(t, FirstDiffPath(x, y, cmpopts.EquateEmpty())())
+	})
+}
+
+type apiResponse struct {
+	Created time.Time
+	Err     error
+	Tags    []string
+}
+
+func TestDeepEqualSemantic(t *testing.T) {
+	now := time.Now()
+
+	t.Run("success with default options", func(t *testing.T) {
+		x := apiResponse{Created: now, Err: fmt.Errorf("not found")}
+		y := apiResponse{
+			Created: now.UTC().Round(0),
+			Err:     fmt.Errorf("not found"),
+			Tags:    []string{},
+		}
+		assertSuccess(t, DeepEqualSemantic(x, y)())
+	})
+
+	t.Run("wrapped error", func(t *testing.T) {
+		cause := fmt.Errorf("cause")
+		x := apiResponse{Err: wrappedError{err: cause}}
+		y := apiResponse{Err: cause}
+		assertSuccess(t, DeepEqualSemantic(x, y)())
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		x := apiResponse{Created: now, Err: fmt.Errorf("not found")}
+		y := apiResponse{Created: now, Err: fmt.Errorf("forbidden")}
+		result := DeepEqualSemantic(x, y)()
+		if result.Success() {
+			t.Fatalf("expected failure")
+		}
+		message := result.(templatedResult).FailureMessage(nil)
+		if !strings.Contains(message, `"forbidden"`) {
+			t.Errorf("expected diff of the error messages, got\n%s", message)
+		}
+	})
+
+	t.Run("options override defaults", func(t *testing.T) {
+		x := apiResponse{Created: now}
+		y := apiResponse{Created: now.Add(time.Hour)}
+		if DeepEqualSemantic(x, y)().Success() {
+			t.Fatalf("expected failure")
+		}
+
+		ignoreTime := cmp.Comparer(func(x, y time.Time) bool { return true })
+		assertSuccess(t, DeepEqualSemantic(x, y, ignoreTime)())
+	})
+
+	t.Run("values are compared once", func(t *testing.T) {
+		type pair struct{ A, B int }
+		var calls int
+		counting := cmp.Comparer(func(x, y int) bool {
+			calls++
+			return x == y
+		})
+		x, y := pair{A: 1, B: 2}, pair{A: 1, B: 3}
+		DeepEqual(x, y, append(semanticDefaults(), counting)...)()
+		expected := calls
+
+		calls = 0
+		if DeepEqualSemantic(x, y, counting)().Success() {
+			t.Fatalf("expected failure")
+		}
+		if calls != expected {
+			t.Errorf("expected the comparer to be called %d times, got %d", expected, calls)
+		}
+	})
+}
+
+type measurement struct {
+	Name    string
+	Mean    float64
+	Samples []float32
+}
+
+func TestDeepEqualApprox(t *testing.T) {
+	x := measurement{Name: "latency", Mean: 1.0, Samples: []float32{0.5, 1.5}}
+
+	t.Run("success within delta", func(t *testing.T) {
+		y := measurement{Name: "latency", Mean: 1.0005, Samples: []float32{0.5001, 1.4999}}
+		assertSuccess(t, DeepEqualApprox(x, y, 0.001)())
+	})
+
+	t.Run("failure shows delta", func(t *testing.T) {
+		y := measurement{Name: "latency", Mean: 1.5, Samples: []float32{0.5, 1.5}}
+		result := DeepEqualApprox(x, y, 0.001)()
+		if result.Success() {
+			t.Fatalf("expected failure")
+		}
+		message := result.(templatedResult).FailureMessage(nil)
+		expected := "float values which differ by more than 0.001:\n" +
+			"  {cmp.measurement}.Mean: 1 != 1.5 (delta 0.5)"
+		if !strings.Contains(message, expected) {
+			t.Errorf("expected message to contain %q, got\n%s", expected, message)
+		}
+	})
+
+	t.Run("failure of other fields", func(t *testing.T) {
+		y := measurement{Name: "throughput", Mean: 1.0, Samples: []float32{0.5, 1.5}}
+		result := DeepEqualApprox(x, y, 0.001)()
+		if result.Success() {
+			t.Fatalf("expected failure")
+		}
+		message := result.(templatedResult).FailureMessage(nil)
+		if strings.Contains(message, "float values") {
+			t.Errorf("expected no float values in message, got\n%s", message)
+		}
+	})
+
+	t.Run("named float types", func(t *testing.T) {
+		assertSuccess(t, DeepEqualApprox(celsius(1), celsius(1.0000001), 0.1)())
+
+		result := DeepEqualApprox([]celsius{1, 2}, []celsius{1, 3}, 0.1)()
+		if result.Success() {
+			t.Fatalf("expected failure")
+		}
+		message := result.(templatedResult).FailureMessage(nil)
+		expected := "float values which differ by more than 0.1:\n" +
+			"  {[]cmp.celsius}[1]: 2 != 3 (delta 1)"
+		if !strings.Contains(message, expected) {
+			t.Errorf("expected message to contain %q, got\n%s", expected, message)
+		}
+	})
+
+	t.Run("floats in interface values", func(t *testing.T) {
+		x := map[string]interface{}{"mean": 1.0, "name": "latency"}
+		y := map[string]interface{}{"mean": 1.0001, "name": "latency"}
+		assertSuccess(t, DeepEqualApprox(x, y, 0.001)())
+	})
+
+	t.Run("options override defaults", func(t *testing.T) {
+		y := measurement{Name: "latency", Mean: 2.0, Samples: []float32{0.5, 1.5}}
+		ignoreFloat64 := cmp.Comparer(func(x, y float64) bool { return true })
+		assertSuccess(t, DeepEqualApprox(x, y, 0.001, ignoreFloat64)())
+	})
+
+	t.Run("negative delta", func(t *testing.T) {
+		result := DeepEqualApprox(x, x, -1)()
+		assertFailure(t, result, "invalid delta -1, delta must not be negative")
+	})
+}
+
+func TestDeepEqualIgnore(t *testing.T) {
+	x := record{Name: "one", Metadata: metadata{ID: "a1", CreatedAt: 1}}
+	y := record{Name: "one", Metadata: metadata{ID: "b2", CreatedAt: 2}}
+
+	t.Run("ignored fields", func(t *testing.T) {
+		result := DeepEqualIgnore(x, y, "Metadata")()
+		assertSuccess(t, result)
+	})
+
+	t.Run("ignored nested fields", func(t *testing.T) {
+		result := DeepEqualIgnore(&x, &y, "Metadata.ID", "Metadata.CreatedAt")()
+		assertSuccess(t, result)
+	})
+
+	t.Run("not all different fields are ignored", func(t *testing.T) {
+		result := DeepEqualIgnore(x, y, "Metadata.ID")()
+		if result.Success() {
+			t.Errorf("expected failure")
+		}
+	})
+
+	t.Run("misspelled field", func(t *testing.T) {
+		result := DeepEqualIgnore(x, y, "Metadata.CreatedOn")()
+		assertFailure(t, result,
+			`can not ignore field "Metadata.CreatedOn", type cmp.metadata has no field "CreatedOn"`)
+	})
+
+	t.Run("field through a pointer", func(t *testing.T) {
+		result := DeepEqualIgnore(x, y, "Parent.Name.Length")()
+		assertFailure(t, result,
+			`can not ignore field "Parent.Name.Length" of cmp.record, string is not a struct`)
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		result := DeepEqualIgnore([]int{1}, []int{1}, "Len")()
+		assertFailure(t, result,
+			"DeepEqualIgnore requires a struct or a pointer to a struct, got []int")
+	})
 }
 
 func TestRegexp(t *testing.T) {
@@ -138,6 +636,36 @@ func TestLen(t *testing.T) {
 			length:          3,
 			expectedMessage: "expected abcd (length 4) to have length 3",
 		},
+		{
+			seq:             &[]int{1, 2},
+			length:          2,
+			expectedSuccess: true,
+		},
+		{
+			seq:             &[]string{"a", "b"},
+			length:          3,
+			expectedMessage: "expected [a b] (length 2) to have length 3",
+		},
+		{
+			seq:             3,
+			length:          1,
+			expectedMessage: "type int does not have a length, use cmp.Equal for comparing ints",
+		},
+		{
+			seq:             struct{}{},
+			length:          0,
+			expectedMessage: "type struct {} does not have a length, use cmp.DeepEqual for comparing structs",
+		},
+		{
+			seq:             (*[]string)(nil),
+			length:          0,
+			expectedMessage: "type *[]string does not have a length",
+		},
+		{
+			seq:             make(chan int),
+			length:          0,
+			expectedSuccess: true,
+		},
 	}
 
 	for _, testcase := range testcases {
@@ -152,6 +680,66 @@ func TestLen(t *testing.T) {
 	}
 }
 
+func TestLenBetween(t *testing.T) {
+	assertSuccess(t, LenBetween([]int{1, 2}, 2, 5)())
+	assertSuccess(t, LenBetween("abcde", 2, 5)())
+	assertSuccess(t, LenBetween(&[]string{"a"}, 1, 1)())
+	assertSuccess(t, LenBetween(map[int]bool{}, 0, 3)())
+
+	assertFailure(t, LenBetween([]int{1, 2, 3, 4, 5, 6, 7}, 2, 5)(),
+		"expected length between 2 and 5, got 7")
+	assertFailure(t, LenBetween("a", 2, 5)(), "expected length between 2 and 5, got 1")
+	assertFailure(t, LenBetween([]int{1}, 5, 2)(), "invalid range: min 5 is greater than max 2")
+	assertFailure(t, LenBetween(3, 0, 5)(),
+		"type int does not have a length, use cmp.Equal for comparing ints")
+}
+
+func TestEmpty(t *testing.T) {
+	var nilPtr *string
+	notNil := "value"
+	var testcases = []struct {
+		name  string
+		obj   interface{}
+		empty bool
+	}{
+		{name: "nil", obj: nil, empty: true},
+		{name: "empty string", obj: "", empty: true},
+		{name: "string", obj: "a"},
+		{name: "nil slice", obj: []int(nil), empty: true},
+		{name: "slice", obj: []int{1}},
+		{name: "empty map", obj: map[string]int{}, empty: true},
+		{name: "map", obj: map[string]int{"a": 1}},
+		{name: "empty array", obj: [0]int{}, empty: true},
+		{name: "array", obj: [1]int{}},
+		{name: "empty channel", obj: make(chan int, 1), empty: true},
+		{name: "nil pointer", obj: nilPtr, empty: true},
+		{name: "pointer", obj: &notNil},
+		{name: "pointer to empty slice", obj: &[]int{}, empty: true},
+		{name: "pointer to slice", obj: &[]int{1}},
+		{name: "pointer to empty map", obj: &map[string]int{}, empty: true},
+		{name: "pointer to struct", obj: &struct{}{}},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			empty, notEmpty := Empty(testcase.obj)(), NotEmpty(testcase.obj)()
+			if testcase.empty {
+				assertSuccess(t, empty)
+				assertFailure(t, notEmpty, fmt.Sprintf(
+					"expected %v (%T) to not be empty", testcase.obj, testcase.obj))
+			} else {
+				assertSuccess(t, notEmpty)
+				assertFailure(t, empty, fmt.Sprintf(
+					"expected %v (%T) to be empty", testcase.obj, testcase.obj))
+			}
+		})
+	}
+
+	t.Run("type without a length", func(t *testing.T) {
+		assertFailure(t, Empty(3)(), "type int does not have a length")
+		assertFailure(t, NotEmpty(struct{}{})(), "type struct {} does not have a length")
+	})
+}
+
 func TestPanics(t *testing.T) {
 	panicker := func() {
 		panic("AHHHHHHHHHHH")
@@ -162,6 +750,110 @@ func TestPanics(t *testing.T) {
 
 	result = Panics(func() {})()
 	assertFailure(t, result, "did not panic")
+
+	result = Panics(func() { panic(nil) })()
+	assertSuccess(t, result)
+}
+
+func TestPanicsAndReturn(t *testing.T) {
+	t.Run("panics", func(t *testing.T) {
+		panics, recovered := PanicsAndReturn(func() { panic(errors.New("boom")) })
+		assertSuccess(t, panics())
+		err, ok := (*recovered).(error)
+		if !ok {
+			t.Fatalf("expected recovered value to be an error, got %T", *recovered)
+		}
+		assertSuccess(t, Error(err, "boom")())
+	})
+
+	t.Run("panics with nil", func(t *testing.T) {
+		panics, _ := PanicsAndReturn(func() { panic(nil) })
+		assertSuccess(t, panics())
+	})
+
+	t.Run("did not panic", func(t *testing.T) {
+		panics, recovered := PanicsAndReturn(func() {})
+		assertFailure(t, panics(), "did not panic")
+		if *recovered != nil {
+			t.Errorf("expected recovered value to be nil, got %v", *recovered)
+		}
+	})
+}
+
+func TestPanicsWithValue(t *testing.T) {
+	result := PanicsWithValue("boom", func() { panic("boom") })()
+	assertSuccess(t, result)
+
+	result = PanicsWithValue([]int{1, 2}, func() { panic([]int{1, 2}) })()
+	assertSuccess(t, result)
+
+	result = PanicsWithValue("boom", func() {})()
+	assertFailure(t, result, "did not panic")
+
+	result = PanicsWithValue("boom", func() { panic(3) })()
+	assertFailure(t, result, "expected panic with boom (string), got panic with 3 (int)")
+}
+
+type stubStringer struct{}
+
+func (s stubStringer) String() string {
+	return "stub stringer"
+}
+
+func TestPanicsWithMessage(t *testing.T) {
+	var testcases = []struct {
+		name        string
+		value       interface{}
+		substring   string
+		expected    bool
+		expectedMsg string
+	}{
+		{
+			name:      "string",
+			value:     "index out of range",
+			substring: "out of range",
+			expected:  true,
+		},
+		{
+			name:      "error",
+			value:     errors.New("invalid argument"),
+			substring: "invalid",
+			expected:  true,
+		},
+		{
+			name:      "stringer",
+			value:     stubStringer{},
+			substring: "stringer",
+			expected:  true,
+		},
+		{
+			name:      "other value",
+			value:     42,
+			substring: "42",
+			expected:  true,
+		},
+		{
+			name:        "no match",
+			value:       errors.New("invalid argument"),
+			substring:   "timeout",
+			expectedMsg: `expected panic message to contain "timeout", got "invalid argument"`,
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result := PanicsWithMessage(testcase.substring, func() { panic(testcase.value) })()
+			if testcase.expected {
+				assertSuccess(t, result)
+			} else {
+				assertFailure(t, result, testcase.expectedMsg)
+			}
+		})
+	}
+
+	t.Run("did not panic", func(t *testing.T) {
+		result := PanicsWithMessage("any", func() {})()
+		assertFailure(t, result, "did not panic")
+	})
 }
 
 type innerstub struct {
@@ -209,19 +901,135 @@ func TestContains(t *testing.T) {
 		expectedMsg string
 	}{
 		{
-			seq:         error(nil),
-			item:        0,
-			expectedMsg: "nil does not contain items",
+			seq:         error(nil),
+			item:        0,
+			expectedMsg: "nil does not contain items",
+		},
+		{
+			seq:      "abcdef",
+			item:     "cde",
+			expected: true,
+		},
+		{
+			seq:         "abcdef",
+			item:        "foo",
+			expectedMsg: `string "abcdef" does not contain "foo"`,
+		},
+		{
+			seq:         "abcdef",
+			item:        3,
+			expectedMsg: `string may only contain strings`,
+		},
+		{
+			seq:      map[rune]int{'a': 1, 'b': 2},
+			item:     'b',
+			expected: true,
+		},
+		{
+			seq:         map[rune]int{'a': 1},
+			item:        'c',
+			expectedMsg: "map[97:1] does not contain 99",
+		},
+		{
+			seq:         map[int]int{'a': 1, 'b': 2},
+			item:        'b',
+			expectedMsg: "map[int]int can not contain a int32 key",
+		},
+		{
+			seq:      []interface{}{"a", 1, 'a', 1.0, true},
+			item:     'a',
+			expected: true,
+		},
+		{
+			seq:         []interface{}{"a", 1, 'a', 1.0, true},
+			item:        3,
+			expectedMsg: "[a 1 97 1 true] does not contain 3",
+		},
+		{
+			seq:      [3]byte{99, 10, 100},
+			item:     byte(99),
+			expected: true,
+		},
+		{
+			seq:         [3]byte{99, 10, 100},
+			item:        byte(98),
+			expectedMsg: "[99 10 100] does not contain 98",
+		},
+	}
+	for _, testcase := range testcases {
+		name := fmt.Sprintf("%v in %v", testcase.item, testcase.seq)
+		t.Run(name, func(t *testing.T) {
+			result := Contains(testcase.seq, testcase.item)()
+			if testcase.expected {
+				assertSuccess(t, result)
+			} else {
+				assertFailure(t, result, testcase.expectedMsg)
+			}
+		})
+	}
+}
+
+func TestContainsWithOptions(t *testing.T) {
+	servers := []server{{"alpha", 80}, {"beta", 81}}
+
+	t.Run("success", func(t *testing.T) {
+		result := Contains(servers, server{Name: "beta"}, cmpopts.IgnoreFields(server{}, "Port"))()
+		assertSuccess(t, result)
+	})
+
+	t.Run("failure shows the closest element", func(t *testing.T) {
+		result := Contains(servers, server{Name: "betta", Port: 9}, cmpopts.IgnoreFields(server{}, "Port"))()
+		if result.Success() {
+			t.Fatalf("expected failure")
+		}
+		msg := result.(StringResult).FailureMessage()
+		prefix := "[{alpha 80} {beta 81}] does not contain {betta 9}\nclosest element is at index 1:\n"
+		if !strings.HasPrefix(msg, prefix) {
+			t.Fatalf("expected message to start with %q, got %q", prefix, msg)
+		}
+		if !strings.Contains(msg, `"beta"`) || !strings.Contains(msg, `"betta"`) {
+			t.Errorf("expected diff of the closest element, got %q", msg)
+		}
+	})
+
+	t.Run("unexported fields", func(t *testing.T) {
+		result := Contains([]stub{{num: 1}}, stub{num: 1}, cmp.Comparer(func(x, y int) bool { return x == y }))()
+		assertFailureHasPrefix(t, result, "cannot handle unexported field")
+	})
+
+	t.Run("options not used for strings", func(t *testing.T) {
+		result := Contains("abc", "d", cmpopts.EquateEmpty())()
+		assertFailure(t, result, `string "abc" does not contain "d"`)
+	})
+}
+
+func TestNotContains(t *testing.T) {
+	var testcases = []struct {
+		seq         interface{}
+		item        interface{}
+		expected    bool
+		expectedMsg string
+	}{
+		{
+			// nil contains nothing, unlike Contains which fails for nil
+			seq:      nil,
+			item:     0,
+			expected: true,
+		},
+		{
+			seq:      []int(nil),
+			item:     0,
+			expected: true,
 		},
 		{
 			seq:      "abcdef",
-			item:     "cde",
+			item:     "foo",
 			expected: true,
 		},
 		{
 			seq:         "abcdef",
-			item:        "foo",
-			expectedMsg: `string "abcdef" does not contain "foo"`,
+			item:        "cd",
+			expectedMsg: `string "abcdef" unexpectedly contains "cd"`,
 		},
 		{
 			seq:         "abcdef",
@@ -229,14 +1037,19 @@ func TestContains(t *testing.T) {
 			expectedMsg: `string may only contain strings`,
 		},
 		{
-			seq:      map[rune]int{'a': 1, 'b': 2},
-			item:     'b',
+			seq:         []error{nil, nil},
+			item:        nil,
+			expectedMsg: "[<nil> <nil>] unexpectedly contains <nil> at index 0",
+		},
+		{
+			seq:      map[rune]int{'a': 1},
+			item:     'c',
 			expected: true,
 		},
 		{
-			seq:         map[rune]int{'a': 1},
-			item:        'c',
-			expectedMsg: "map[97:1] does not contain 99",
+			seq:         map[rune]int{'a': 1, 'b': 2},
+			item:        'b',
+			expectedMsg: "map[97:1 98:2] unexpectedly contains 98",
 		},
 		{
 			seq:         map[int]int{'a': 1, 'b': 2},
@@ -244,30 +1057,30 @@ func TestContains(t *testing.T) {
 			expectedMsg: "map[int]int can not contain a int32 key",
 		},
 		{
-			seq:      []interface{}{"a", 1, 'a', 1.0, true},
-			item:     'a',
+			seq:      []int{1, 2, 3},
+			item:     4,
 			expected: true,
 		},
 		{
-			seq:         []interface{}{"a", 1, 'a', 1.0, true},
-			item:        3,
-			expectedMsg: "[a 1 97 1 true] does not contain 3",
+			seq:         []int{1, 2, 3},
+			item:        2,
+			expectedMsg: "[1 2 3] unexpectedly contains 2 at index 1",
 		},
 		{
-			seq:      [3]byte{99, 10, 100},
-			item:     byte(99),
-			expected: true,
+			seq:         [3]byte{99, 10, 100},
+			item:        byte(100),
+			expectedMsg: "[99 10 100] unexpectedly contains 100 at index 2",
 		},
 		{
-			seq:         [3]byte{99, 10, 100},
-			item:        byte(98),
-			expectedMsg: "[99 10 100] does not contain 98",
+			seq:         3,
+			item:        3,
+			expectedMsg: "type int does not contain items",
 		},
 	}
 	for _, testcase := range testcases {
-		name := fmt.Sprintf("%v in %v", testcase.item, testcase.seq)
+		name := fmt.Sprintf("%v not in %v", testcase.item, testcase.seq)
 		t.Run(name, func(t *testing.T) {
-			result := Contains(testcase.seq, testcase.item)()
+			result := NotContains(testcase.seq, testcase.item)()
 			if testcase.expected {
 				assertSuccess(t, result)
 			} else {
@@ -304,6 +1117,39 @@ bbbb`
 	assertFailureTemplate(t, res, args, expected)
 }
 
+func TestEqualLongStrings(t *testing.T) {
+	result := strings.Repeat("a", 80) + "b"
+	exp := strings.Repeat("a", 80) + "c"
+
+	expected := `
+--- result
++++ exp
+@@ -1 +1 @@
+-` + result + `
++` + exp + `
+`
+
+	args := []ast.Expr{&ast.Ident{Name: "result"}, &ast.Ident{Name: "exp"}}
+	res := Equal(result, exp)()
+	assertFailureTemplate(t, res, args, expected)
+}
+
+func TestEqualShortStrings(t *testing.T) {
+	res := Equal("short", "string")()
+	args := []ast.Expr{&ast.Ident{Name: "result"}, &ast.Ident{Name: "exp"}}
+	assertFailureTemplate(t, res, args, "short (result string) != string (exp string)")
+}
+
+func TestEqualMultiLineWithColor(t *testing.T) {
+	defer patchEnv(format.ColorEnvVar, "always")()
+
+	expected := "\n--- result\n+++ exp\n@@ -1,2 +1,2 @@\n a\n" +
+		"\x1b[31m-b\x1b[0m\n\x1b[32m+c\x1b[0m\n"
+	args := []ast.Expr{&ast.Ident{Name: "result"}, &ast.Ident{Name: "exp"}}
+	res := Equal("a\nb", "a\nc")()
+	assertFailureTemplate(t, res, args, expected)
+}
+
 func TestEqual_PointersNotEqual(t *testing.T) {
 	x := 123
 	y := 123
@@ -314,6 +1160,170 @@ func TestEqual_PointersNotEqual(t *testing.T) {
 	assertFailureTemplate(t, res, args, expected)
 }
 
+type idStringer struct {
+	id int
+}
+
+func (s idStringer) String() string {
+	return "stringer"
+}
+
+func TestEqualErrorsAndStringers(t *testing.T) {
+	args := []ast.Expr{&ast.Ident{Name: "err"}, &ast.Ident{Name: "expected"}}
+
+	t.Run("errors with the same message", func(t *testing.T) {
+		err := fmt.Errorf("stub error")
+		res := Equal(err, stubError{})()
+		expected := fmt.Sprintf(`stub error (err *errors.errorString) != stub error (expected cmp.stubError)
+x as Go syntax: &errors.errorString{s:"stub error"} at %p
+y as Go syntax: cmp.stubError{}`, err)
+		assertFailureTemplate(t, res, args, expected)
+	})
+
+	t.Run("pointer errors with the same message", func(t *testing.T) {
+		err, other := fmt.Errorf("stub error"), fmt.Errorf("stub error")
+		res := Equal(err, other)()
+		expected := fmt.Sprintf(`stub error (err *errors.errorString) != stub error (expected *errors.errorString)
+x as Go syntax: &errors.errorString{s:"stub error"} at %p
+y as Go syntax: &errors.errorString{s:"stub error"} at %p`, err, other)
+		assertFailureTemplate(t, res, args, expected)
+	})
+
+	t.Run("errors with different messages", func(t *testing.T) {
+		res := Equal(fmt.Errorf("one"), fmt.Errorf("two"))()
+		assertFailureTemplate(t, res, args,
+			"one (err *errors.errorString) != two (expected *errors.errorString)")
+	})
+
+	t.Run("stringers which print the same", func(t *testing.T) {
+		res := Equal(idStringer{id: 1}, idStringer{id: 2})()
+		expected := `stringer (err cmp.idStringer) != stringer (expected cmp.idStringer)
+x as Go syntax: cmp.idStringer{id:1}
+y as Go syntax: cmp.idStringer{id:2}`
+		assertFailureTemplate(t, res, args, expected)
+	})
+
+	t.Run("other values are unchanged", func(t *testing.T) {
+		res := Equal(1, "1")()
+		assertFailureTemplate(t, res, args, "1 (err int) != 1 (expected string)")
+	})
+}
+
+type uncomparableStruct struct {
+	Name  string
+	Value interface{}
+}
+
+func TestEqualUncomparableTypes(t *testing.T) {
+	var testcases = []struct {
+		name     string
+		x, y     interface{}
+		expected string
+	}{
+		{
+			name:     "slice",
+			x:        []int{1},
+			y:        []int{1},
+			expected: "values of type []int can not be compared with ==, use cmp.DeepEqual instead",
+		},
+		{
+			name:     "map",
+			x:        map[string]int{"a": 1},
+			y:        map[string]int{"a": 1},
+			expected: "values of type map[string]int can not be compared with ==, use cmp.DeepEqual instead",
+		},
+		{
+			name:     "func",
+			x:        func() {},
+			y:        func() {},
+			expected: "values of type func() can not be compared with ==, use cmp.DeepEqual instead",
+		},
+		{
+			name: "struct with an uncomparable field",
+			x:    uncomparableStruct{Name: "a", Value: []int{1}},
+			y:    uncomparableStruct{Name: "a", Value: []int{1}},
+			expected: "values of type cmp.uncomparableStruct can not be compared with ==, " +
+				"{cmp.uncomparableStruct}.Value has uncomparable type []int, use cmp.DeepEqual instead",
+		},
+		{
+			name: "array with an uncomparable element",
+			x:    [2]interface{}{1, map[string]int{}},
+			y:    [2]interface{}{1, map[string]int{}},
+			expected: "values of type [2]interface {} can not be compared with ==, " +
+				"{[2]interface {}}[1] has uncomparable type map[string]int, use cmp.DeepEqual instead",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			assertFailure(t, Equal(testcase.x, testcase.y)(), testcase.expected)
+		})
+	}
+
+	t.Run("different uncomparable types", func(t *testing.T) {
+		result := Equal([]int{1}, map[int]int{})()
+		if result.Success() {
+			t.Errorf("expected failure")
+		}
+	})
+}
+
+func TestPtrEqual(t *testing.T) {
+	x, y, z := 123, 123, 456
+	var nilInt *int
+	var ifaceX, ifaceY interface{} = []int{1}, []int{1}
+	type withInterface struct{ V interface{} }
+	var testcases = []struct {
+		name        string
+		x, y        interface{}
+		expectedMsg string
+	}{
+		{name: "equal values", x: &x, y: &y},
+		{name: "same pointer", x: &x, y: &x},
+		{name: "both nil", x: nilInt, y: (*int)(nil)},
+		{name: "uncomparable values", x: &[]string{"a"}, y: &[]string{"a"}},
+		{name: "interface with uncomparable value", x: &ifaceX, y: &ifaceY},
+		{
+			name: "struct with uncomparable interface field",
+			x:    &withInterface{V: []int{1}},
+			y:    &withInterface{V: []int{1}},
+		},
+		{
+			name:        "different values",
+			x:           &x,
+			y:           &z,
+			expectedMsg: "123 (int) != 456 (int), values of pointers are not equal",
+		},
+		{
+			name:        "one nil",
+			x:           &x,
+			y:           nilInt,
+			expectedMsg: fmt.Sprintf("one pointer is nil: %p (*int) != <nil> (*int)", &x),
+		},
+		{
+			name:        "different types",
+			x:           &x,
+			y:           new(string),
+			expectedMsg: "can not compare *int to *string",
+		},
+		{
+			name:        "not pointers",
+			x:           x,
+			y:           &y,
+			expectedMsg: "PtrEqual requires pointers, got int and *int",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result := PtrEqual(testcase.x, testcase.y)()
+			if testcase.expectedMsg == "" {
+				assertSuccess(t, result)
+			} else {
+				assertFailure(t, result, testcase.expectedMsg)
+			}
+		})
+	}
+}
+
 func TestError(t *testing.T) {
 	result := Error(nil, "the error message")()
 	assertFailure(t, result, "expected an error, got nil")
@@ -343,6 +1353,49 @@ func TestErrorContains(t *testing.T) {
 	assertSuccess(t, result)
 }
 
+func TestErrorContainsAll(t *testing.T) {
+	result := ErrorContainsAll(nil, "the error message")()
+	assertFailure(t, result, "expected an error, got nil")
+
+	err := errors.New("failed to delete volume vol-123: device busy")
+	result = ErrorContainsAll(err, "delete", "vol-123", "busy")()
+	assertSuccess(t, result)
+
+	result = ErrorContainsAll(err)()
+	assertSuccess(t, result)
+
+	result = ErrorContainsAll(err, "create", "vol-123", "timeout")()
+	assertFailureHasPrefix(t, result, `expected error to contain "create", "timeout", `+
+		`got "failed to delete volume vol-123: device busy"`)
+}
+
+type multiError []error
+
+func (e multiError) Error() string {
+	return "multiple errors"
+}
+
+func (e multiError) Unwrap() []error {
+	return e
+}
+
+func TestNilError(t *testing.T) {
+	assertSuccess(t, NilError(nil)())
+	assertSuccess(t, NoError(nil)())
+
+	var nilPtr *stubPtrError
+	assertSuccess(t, NilError(nilPtr)())
+
+	result := NilError(fmt.Errorf("the error"))()
+	assertFailure(t, result, "error is not nil: the error")
+
+	result = NoError(errors.Wrap(fmt.Errorf("cause"), "wrapped"))()
+	assertFailureHasPrefix(t, result, "error is not nil: cause\nwrapped\ngotest.tools")
+
+	result = NilError(multiError{fmt.Errorf("first"), fmt.Errorf("second")})()
+	assertFailure(t, result, "error is not nil: 2 errors:\n  - first\n  - second")
+}
+
 func TestNil(t *testing.T) {
 	result := Nil(nil)()
 	assertSuccess(t, result)
@@ -366,6 +1419,53 @@ func TestNil(t *testing.T) {
 	assertFailure(t, result, "[a] (type []string) is not nil")
 }
 
+func TestZero(t *testing.T) {
+	type config struct {
+		Name  string
+		Count int
+		Tags  []string
+	}
+	var nilPtr *config
+	var testcases = []struct {
+		obj         interface{}
+		expectedMsg string
+	}{
+		{obj: nil},
+		{obj: 0},
+		{obj: ""},
+		{obj: config{}},
+		{obj: [2]int{}},
+		{obj: nilPtr},
+		{obj: []string(nil)},
+		{
+			obj:         config{Count: 1},
+			expectedMsg: "{Name: Count:1 Tags:[]} (type cmp.config) is not the zero value for its type",
+		},
+		{
+			obj:         []string{},
+			expectedMsg: "[] (type []string) is not the zero value for its type",
+		},
+		{
+			obj:         1.5,
+			expectedMsg: "1.5 (type float64) is not the zero value for its type",
+		},
+		{
+			obj:         [2]int{0, 1},
+			expectedMsg: "[0 1] (type [2]int) is not the zero value for its type",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(fmt.Sprintf("%#v", testcase.obj), func(t *testing.T) {
+			result := Zero(testcase.obj)()
+			if testcase.expectedMsg == "" {
+				assertSuccess(t, result)
+			} else {
+				assertFailure(t, result, testcase.expectedMsg)
+			}
+		})
+	}
+}
+
 type testingT interface {
 	Errorf(msg string, args ...interface{})
 }
@@ -534,6 +1634,49 @@ func TestErrorTypeSuccess(t *testing.T) {
 	}
 }
 
+type wrappedError struct {
+	err error
+}
+
+func (e wrappedError) Error() string {
+	return "wrapped: " + e.err.Error()
+}
+
+func (e wrappedError) Unwrap() error {
+	return e.err
+}
+
+func TestErrorTypeWithWrappedError(t *testing.T) {
+	err := wrappedError{err: wrappedError{err: &stubPtrError{}}}
+
+	t.Run("with pointer to struct", func(t *testing.T) {
+		result := ErrorType(err, &stubPtrError{})()
+		assertSuccess(t, result)
+	})
+
+	t.Run("with struct", func(t *testing.T) {
+		result := ErrorType(err, wrappedError{})()
+		assertSuccess(t, result)
+	})
+
+	t.Run("with reflect.Type interface", func(t *testing.T) {
+		result := ErrorType(err, reflect.TypeOf((*wrapper)(nil)).Elem())()
+		assertSuccess(t, result)
+	})
+
+	t.Run("not in chain", func(t *testing.T) {
+		result := ErrorType(err, stubError{})()
+		assertFailure(t, result,
+			"error is wrapped: wrapped: stub ptr error (cmp.wrappedError), not cmp.stubError")
+	})
+
+	t.Run("interface not in chain", func(t *testing.T) {
+		result := ErrorType(err, (*specialStubIface)(nil))()
+		assertFailure(t, result,
+			"error is wrapped: wrapped: stub ptr error (cmp.wrappedError), not cmp.specialStubIface")
+	})
+}
+
 func TestErrorTypeFailure(t *testing.T) {
 	var testcases = []struct {
 		name     string
@@ -593,3 +1736,204 @@ func TestErrorTypeWithFunc(t *testing.T) {
 		[]ast.Expr{nil, &ast.Ident{Name: "isErrorOfTypeNotStub"}},
 		"error is stub error (cmp.stubError), not isErrorOfTypeNotStub")
 }
+
+func TestNot(t *testing.T) {
+	t.Run("wrapped comparison fails", func(t *testing.T) {
+		result := Not(Equal(1, 2))()
+		assertSuccess(t, result)
+	})
+
+	t.Run("wrapped comparison succeeds", func(t *testing.T) {
+		result := Not(Equal(1, 1))()
+		assertFailureTemplate(t, result, nil,
+			"expected comparison cmp.Equal to fail, but it succeeded")
+	})
+
+	t.Run("with comparison variable name", func(t *testing.T) {
+		result := Not(Contains("abc", "b"))()
+		args := []ast.Expr{&ast.Ident{Name: "hasB"}}
+		assertFailureTemplate(t, result, args,
+			"expected comparison hasB to fail, but it succeeded")
+	})
+
+	t.Run("with custom comparison", func(t *testing.T) {
+		custom := func() Result { return ResultSuccess }
+		result := Not(custom)()
+		assertFailureTemplate(t, result, nil,
+			"expected comparison to fail, but it succeeded")
+	})
+
+	t.Run("wrapped comparison panics", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "oops" {
+				t.Errorf("expected panic to propagate, got %v", r)
+			}
+		}()
+		Not(func() Result { panic("oops") })()
+		t.Errorf("expected panic")
+	})
+}
+
+func TestAnd(t *testing.T) {
+	t.Run("all succeed", func(t *testing.T) {
+		result := And(Equal(1, 1), Contains("abc", "b"))()
+		assertSuccess(t, result)
+	})
+
+	t.Run("no comparisons", func(t *testing.T) {
+		assertSuccess(t, And()())
+	})
+
+	t.Run("first failure is returned", func(t *testing.T) {
+		result := And(Equal(1, 1), Equal(1, 2), Contains("abc", "d"))()
+		assertFailure(t, result, "1 (int) != 2 (int)")
+	})
+
+	t.Run("comparisons after a failure are not run", func(t *testing.T) {
+		var s []string
+		result := And(Len(s, 1), func() Result {
+			t.Errorf("expected comparison not to run")
+			return ResultFailure(s[0])
+		})()
+		assertFailure(t, result, "expected [] (length 0) to have length 1")
+	})
+}
+
+func TestOr(t *testing.T) {
+	t.Run("one succeeds", func(t *testing.T) {
+		result := Or(Equal(1, 2), Contains("abc", "b"))()
+		assertSuccess(t, result)
+	})
+
+	t.Run("no comparisons", func(t *testing.T) {
+		assertFailure(t, Or()(), "no comparisons to run")
+	})
+
+	t.Run("all fail", func(t *testing.T) {
+		result := Or(Equal(1, 2), Contains("abc", "d"), Equal("a\nb", "a\nc"))()
+		expected := `none of the comparisons succeeded:
+  1: 1 (int) != 2 (int)
+  2: string "abc" does not contain "d"
+  3:
+    --- ←
+    +++ →
+    @@ -1,2 +1,2 @@
+     a
+    -b
+    +c`
+		assertFailure(t, result, expected)
+	})
+}
+
+func TestTimed(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		result := Equal(1, 1)()
+		if timed := Timed(Equal(1, 1))(); timed != result {
+			t.Errorf("expected the Result to be unchanged, got %#v", timed)
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		slow := func() Result {
+			time.Sleep(5 * time.Millisecond)
+			return ResultFailure("too slow")
+		}
+		result := Timed(slow)()
+		if result.Success() {
+			t.Fatalf("expected failure")
+		}
+		msg := failureMessage(result, nil)
+		if !regexp.MustCompile(`^too slow \(comparison took \d+(\.\d+)?m?s\)$`).MatchString(msg) {
+			t.Errorf("unexpected failure message %q", msg)
+		}
+	})
+
+	t.Run("templated failure is rendered without args", func(t *testing.T) {
+		result := Timed(Equal(1, 2))()
+		msg := failureMessage(result, []ast.Expr{&ast.Ident{Name: "check"}})
+		if !strings.HasPrefix(msg, "1 (int) != 2 (int) (comparison took ") {
+			t.Errorf("unexpected failure message %q", msg)
+		}
+	})
+
+	t.Run("panics are not recovered", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("expected panic to be re-raised, got %v", r)
+			}
+		}()
+		Timed(func() Result { panic("boom") })()
+	})
+}
+
+func TestAnnotated(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		result := Annotated(Equal(1, 1), "record %d", 3)()
+		if result != ResultSuccess {
+			t.Errorf("expected the Result to be unchanged, got %#v", result)
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		result := Annotated(Contains("abc", "d"), "while processing record %d", 3)()
+		assertFailure(t, result,
+			`string "abc" does not contain "d": while processing record 3`)
+	})
+
+	t.Run("templated failure is rendered without args", func(t *testing.T) {
+		result := Annotated(Equal(1, 2), "record %d", 3)()
+		assertFailure(t, result, "1 (int) != 2 (int): record 3")
+	})
+
+	t.Run("multi-line failure", func(t *testing.T) {
+		result := Annotated(Equal("a\nb", "a\nc"), "record %d", 3)()
+		if !strings.HasSuffix(failureMessage(result, nil), "+c\nrecord 3") {
+			t.Errorf("expected annotation after the diff, got %q", failureMessage(result, nil))
+		}
+	})
+}
+
+func TestMatch(t *testing.T) {
+	isEven := func(v interface{}) bool { return v.(int)%2 == 0 }
+
+	assertSuccess(t, Match(4, isEven, "an even number")())
+	assertFailure(t, Match(3, isEven, "an even number")(),
+		"value 3 did not satisfy: an even number")
+	assertFailure(t, Match("3", isEven, "an even number")(),
+		"predicate panicked for value 3: interface conversion: interface {} is string, not int")
+}
+
+func TestMatchString(t *testing.T) {
+	isUpper := func(s string) bool { return s == strings.ToUpper(s) }
+
+	assertSuccess(t, MatchString("ABC", isUpper, "upper case")())
+	assertFailure(t, MatchString("aBC", isUpper, "upper case")(),
+		`value "aBC" did not satisfy: upper case`)
+
+	panics := func(s string) bool { panic("empty string") }
+	assertFailure(t, MatchString("", panics, "not empty")(),
+		`predicate panicked for value "": empty string`)
+}
+
+func TestWithLabel(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		assertSuccess(t, WithLabel("count", Equal(1, 1))())
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		result := WithLabel("name", Contains("Alice", "Bob"))()
+		assertFailure(t, result, `name: string "Alice" does not contain "Bob"`)
+	})
+
+	t.Run("templated failure", func(t *testing.T) {
+		result := WithLabel("count", Equal(1, 2))()
+		assertFailure(t, result, "count: 1 (int) != 2 (int)")
+	})
+
+	t.Run("with And", func(t *testing.T) {
+		result := And(
+			WithLabel("first", Equal(1, 1)),
+			WithLabel("second", Equal("a", "b")))()
+		assertFailure(t, result, "second: a (string) != b (string)")
+	})
+}
@@ -0,0 +1,47 @@
+package cmp
+
+import (
+	"fmt"
+	"os"
+)
+
+// FileModeOption is an option for FileMode.
+type FileModeOption func(*fileModeConfig)
+
+type fileModeConfig struct {
+	mask os.FileMode
+}
+
+// WithModeMask sets the bits of the file modes which are compared by FileMode.
+// Use os.ModePerm to compare only the permission bits, and ignore the type
+// bits, such as os.ModeDir.
+func WithModeMask(mask os.FileMode) FileModeOption {
+	return func(conf *fileModeConfig) {
+		conf.mask = mask
+	}
+}
+
+// FileMode succeeds if actual and expected are the same file mode. The failure
+// message shows each mode in the format used by ls, and the permission bits in
+// octal, for example -rwxr-xr-x (0755).
+//
+// By default all the bits of the modes are compared, which can be changed with
+// WithModeMask.
+func FileMode(actual, expected os.FileMode, opts ...FileModeOption) Comparison {
+	return func() Result {
+		conf := &fileModeConfig{mask: ^os.FileMode(0)}
+		for _, opt := range opts {
+			opt(conf)
+		}
+		actual, expected := actual&conf.mask, expected&conf.mask
+		if actual == expected {
+			return ResultSuccess
+		}
+		return ResultFailure(fmt.Sprintf("expected file mode %s, got %s",
+			formatFileMode(expected), formatFileMode(actual)))
+	}
+}
+
+func formatFileMode(mode os.FileMode) string {
+	return fmt.Sprintf("%s (%04o)", mode, mode.Perm())
+}
@@ -0,0 +1,57 @@
+package cmp
+
+import (
+	"os"
+	"testing"
+)
+
+func TestFileMode(t *testing.T) {
+	var testcases = []struct {
+		name        string
+		actual      os.FileMode
+		expected    os.FileMode
+		opts        []FileModeOption
+		expectedMsg string
+	}{
+		{
+			name:     "equal",
+			actual:   0755,
+			expected: 0755,
+		},
+		{
+			name:        "different permissions",
+			actual:      0755,
+			expected:    0644,
+			expectedMsg: "expected file mode -rw-r--r-- (0644), got -rwxr-xr-x (0755)",
+		},
+		{
+			name:        "different type",
+			actual:      os.ModeDir | 0755,
+			expected:    0755,
+			expectedMsg: "expected file mode -rwxr-xr-x (0755), got drwxr-xr-x (0755)",
+		},
+		{
+			name:     "type ignored by mask",
+			actual:   os.ModeDir | 0755,
+			expected: 0755,
+			opts:     []FileModeOption{WithModeMask(os.ModePerm)},
+		},
+		{
+			name:        "permissions compared with mask",
+			actual:      os.ModeDir | 0700,
+			expected:    0755,
+			opts:        []FileModeOption{WithModeMask(os.ModePerm)},
+			expectedMsg: "expected file mode -rwxr-xr-x (0755), got -rwx------ (0700)",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result := FileMode(testcase.actual, testcase.expected, testcase.opts...)()
+			if testcase.expectedMsg == "" {
+				assertSuccess(t, result)
+				return
+			}
+			assertFailure(t, result, testcase.expectedMsg)
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package cmp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"gotest.tools/v3/internal/format"
+)
+
+// JSONEqual succeeds if x and y are equivalent JSON documents. Both values are
+// decoded and the results are compared, so whitespace and the order of object
+// keys are ignored. Numbers are compared by their exact numeric value, so 1 and
+// 1.0 are equal, and large integers are not rounded.
+//
+// If the documents are not equal the failure message includes a unified diff
+// of both documents after they have been formatted consistently.
+func JSONEqual(x, y string) Comparison {
+	return func() Result {
+		xValue, err := decodeJSON(x)
+		if err != nil {
+			return ResultFailure(fmt.Sprintf("failed to parse x as JSON: %s", err))
+		}
+		yValue, err := decodeJSON(y)
+		if err != nil {
+			return ResultFailure(fmt.Sprintf("failed to parse y as JSON: %s", err))
+		}
+		if jsonValuesEqual(xValue, yValue) {
+			return ResultSuccess
+		}
+
+		diff := format.UnifiedDiff(format.DiffConfig{
+			A: formatJSON(xValue),
+			B: formatJSON(yValue),
+		})
+		return multiLineDiffResult(diff)
+	}
+}
+
+// decodeJSON decodes doc, using json.Number for numbers so that large integers
+// are not rounded to the nearest float64.
+func decodeJSON(doc string) (interface{}, error) {
+	// json.Unmarshal validates the document, and rejects any data after the
+	// first value, which json.Decoder would ignore.
+	var raw json.RawMessage
+	if err := json.Unmarshal([]byte(doc), &raw); err != nil {
+		return nil, err
+	}
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	err := decoder.Decode(&value)
+	return value, err
+}
+
+// jsonValuesEqual returns true if the decoded JSON values x and y are equal.
+// Numbers are equal if they have the same numeric value, so 1 and 1.0 are
+// equal.
+func jsonValuesEqual(x, y interface{}) bool {
+	switch xValue := x.(type) {
+	case json.Number:
+		yValue, ok := y.(json.Number)
+		return ok && jsonNumbersEqual(xValue, yValue)
+	case map[string]interface{}:
+		yValue, ok := y.(map[string]interface{})
+		if !ok || len(xValue) != len(yValue) {
+			return false
+		}
+		for key, value := range xValue {
+			other, ok := yValue[key]
+			if !ok || !jsonValuesEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		yValue, ok := y.([]interface{})
+		if !ok || len(xValue) != len(yValue) {
+			return false
+		}
+		for i := range xValue {
+			if !jsonValuesEqual(xValue[i], yValue[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	// strings, booleans, and null
+	return x == y
+}
+
+func jsonNumbersEqual(x, y json.Number) bool {
+	xRat, xOk := new(big.Rat).SetString(string(x))
+	yRat, yOk := new(big.Rat).SetString(string(y))
+	if !xOk || !yOk {
+		return x == y
+	}
+	return xRat.Cmp(yRat) == 0
+}
+
+// formatJSON returns value as indented JSON. Object keys are sorted by
+// json.Marshal, which makes the output suitable for a diff.
+func formatJSON(value interface{}) string {
+	out, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		// value was decoded from JSON, so it should always be possible to
+		// encode it again.
+		return fmt.Sprintf("failed to format JSON: %s", err)
+	}
+	return string(out) + "\n"
+}
+
+// JSONOption is an option for JSONContains.
+type JSONOption func(*jsonConfig)
+
+type jsonConfig struct {
+	arraySubset bool
+}
+
+// WithArraySubset changes how JSONContains compares arrays. Each element of
+// an array in the expected document must match a different element of the
+// array in the actual document, in any order. The actual array may contain
+// other elements.
+func WithArraySubset() JSONOption {
+	return func(conf *jsonConfig) {
+		conf.arraySubset = true
+	}
+}
+
+// JSONContains succeeds if the actual JSON document contains every value in
+// the expected JSON document. Object keys which are only in actual are ignored,
+// and values for keys in expected are compared recursively, so expected only
+// needs to include the fields of nested objects which are important.
+//
+// By default an array in expected matches an array in actual with the same
+// number of elements, where each element matches the element at the same index.
+// Use WithArraySubset to match arrays which contain the expected elements in
+// any order.
+//
+// The failure message includes the path to the first value which did not
+// match, for example $.user.roles[1], and the actual and expected values.
+func JSONContains(actual, expected string, opts ...JSONOption) Comparison {
+	return func() Result {
+		conf := &jsonConfig{}
+		for _, opt := range opts {
+			opt(conf)
+		}
+		actualValue, err := decodeJSON(actual)
+		if err != nil {
+			return ResultFailure(fmt.Sprintf("failed to parse actual as JSON: %s", err))
+		}
+		expectedValue, err := decodeJSON(expected)
+		if err != nil {
+			return ResultFailure(fmt.Sprintf("failed to parse expected as JSON: %s", err))
+		}
+		if msg := conf.contains(actualValue, expectedValue, "$"); msg != "" {
+			return ResultFailure("JSON does not contain expected value at " + msg)
+		}
+		return ResultSuccess
+	}
+}
+
+// contains returns the path and a description of the first value in expected
+// which does not match actual, or an empty string if every value matches.
+func (conf *jsonConfig) contains(actual, expected interface{}, path string) string {
+	switch expectedValue := expected.(type) {
+	case map[string]interface{}:
+		actualValue, ok := actual.(map[string]interface{})
+		if !ok {
+			return mismatchedJSON(path, actual, expected)
+		}
+		keys := make([]string, 0, len(expectedValue))
+		for key := range expectedValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value, ok := actualValue[key]
+			if !ok {
+				return fmt.Sprintf("%s: missing key %q", path, key)
+			}
+			if msg := conf.contains(value, expectedValue[key], path+"."+key); msg != "" {
+				return msg
+			}
+		}
+		return ""
+	case []interface{}:
+		actualValue, ok := actual.([]interface{})
+		if !ok {
+			return mismatchedJSON(path, actual, expected)
+		}
+		if conf.arraySubset {
+			return conf.containsElements(actualValue, expectedValue, path)
+		}
+		if len(actualValue) != len(expectedValue) {
+			return fmt.Sprintf("%s: expected %d elements, got %d: %s",
+				path, len(expectedValue), len(actualValue), compactJSON(actual))
+		}
+		for i := range expectedValue {
+			elemPath := fmt.Sprintf("%s[%d]", path, i)
+			if msg := conf.contains(actualValue[i], expectedValue[i], elemPath); msg != "" {
+				return msg
+			}
+		}
+		return ""
+	}
+	if !jsonValuesEqual(actual, expected) {
+		return mismatchedJSON(path, actual, expected)
+	}
+	return ""
+}
+
+// containsElements matches each element of expected to a different element of
+// actual, in any order. An expected element may match more than one element of
+// actual, so the elements are matched using augmenting paths, which finds a
+// match for every expected element if one exists.
+func (conf *jsonConfig) containsElements(actual, expected []interface{}, path string) string {
+	candidates := make([][]int, len(expected))
+	for i, item := range expected {
+		for j := range actual {
+			if conf.contains(actual[j], item, path) == "" {
+				candidates[i] = append(candidates[i], j)
+			}
+		}
+	}
+
+	// owner is the index of the expected element matched to each element of
+	// actual, or -1 if the element is not matched.
+	owner := make([]int, len(actual))
+	for j := range owner {
+		owner[j] = -1
+	}
+	var augment func(i int, visited []bool) bool
+	augment = func(i int, visited []bool) bool {
+		for _, j := range candidates[i] {
+			if visited[j] {
+				continue
+			}
+			visited[j] = true
+			if owner[j] < 0 || augment(owner[j], visited) {
+				owner[j] = i
+				return true
+			}
+		}
+		return false
+	}
+	for i, item := range expected {
+		if !augment(i, make([]bool, len(actual))) {
+			return fmt.Sprintf("%s: no element matches expected element [%d] %s: %s",
+				path, i, compactJSON(item), compactJSON(actual))
+		}
+	}
+	return ""
+}
+
+func mismatchedJSON(path string, actual, expected interface{}) string {
+	return fmt.Sprintf("%s: expected %s, got %s", path, compactJSON(expected), compactJSON(actual))
+}
+
+func compactJSON(value interface{}) string {
+	out, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("failed to format JSON: %s", err)
+	}
+	return string(out)
+}
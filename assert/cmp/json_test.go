@@ -0,0 +1,176 @@
+package cmp
+
+import (
+	"go/ast"
+	"testing"
+)
+
+func TestJSONEqual(t *testing.T) {
+	t.Run("equal with different formatting", func(t *testing.T) {
+		x := `{"name": "one", "tags": ["a", "b"], "count": 1}`
+		y := `{
+			"count": 1.0,
+			"tags": ["a", "b"],
+			"name": "one"
+		}`
+		result := JSONEqual(x, y)()
+		assertSuccess(t, result)
+	})
+
+	t.Run("not equal", func(t *testing.T) {
+		x := `{"name": "one", "tags": ["a", "b"], "count": 1}`
+		y := `{"name": "one", "tags": ["a", "c"], "count": 1}`
+		result := JSONEqual(x, y)()
+
+		args := []ast.Expr{&ast.Ident{Name: "actual"}, &ast.Ident{Name: "expected"}}
+		expected := `
+--- actual
++++ expected
+@@ -4,5 +4,5 @@
+   "tags": [
+     "a",
+-    "b"
++    "c"
+   ]
+ }
+`
+		assertFailureTemplate(t, result, args, expected)
+	})
+
+	t.Run("array order matters", func(t *testing.T) {
+		result := JSONEqual(`[1, 2]`, `[2, 1]`)()
+		if result.Success() {
+			t.Errorf("expected failure")
+		}
+	})
+
+	t.Run("large integers are not rounded", func(t *testing.T) {
+		// both numbers round to the same float64
+		x := `{"id": 12345678901234567890}`
+		y := `{"id": 12345678901234567891}`
+		result := JSONEqual(x, y)()
+		if result.Success() {
+			t.Errorf("expected failure")
+		}
+		assertSuccess(t, JSONEqual(x, `{"id": 1.2345678901234567890e19}`)())
+	})
+
+	t.Run("trailing data", func(t *testing.T) {
+		result := JSONEqual(`{} {}`, `{}`)()
+		assertFailure(t, result,
+			"failed to parse x as JSON: invalid character '{' after top-level value")
+	})
+
+	t.Run("invalid x", func(t *testing.T) {
+		result := JSONEqual(`{"a":`, `{}`)()
+		assertFailure(t, result, "failed to parse x as JSON: unexpected end of JSON input")
+	})
+
+	t.Run("invalid y", func(t *testing.T) {
+		result := JSONEqual(`{}`, `{a}`)()
+		assertFailure(t, result,
+			"failed to parse y as JSON: invalid character 'a' looking for beginning of object key string")
+	})
+}
+
+func TestJSONContains(t *testing.T) {
+	actual := `{
+		"id": 7,
+		"user": {"name": "Alice", "email": "alice@example.com", "roles": ["admin", "dev"]},
+		"items": [{"id": 1, "qty": 2}, {"id": 2, "qty": 1}]
+	}`
+
+	var testcases = []struct {
+		name        string
+		expected    string
+		opts        []JSONOption
+		expectedMsg string
+	}{
+		{
+			name:     "subset of keys",
+			expected: `{"user": {"name": "Alice"}, "id": 7.0}`,
+		},
+		{
+			name:     "objects in arrays",
+			expected: `{"items": [{"id": 1}, {"id": 2}]}`,
+		},
+		{
+			name:     "empty object",
+			expected: `{}`,
+		},
+		{
+			name:        "different value",
+			expected:    `{"user": {"name": "Bob"}}`,
+			expectedMsg: `JSON does not contain expected value at $.user.name: expected "Bob", got "Alice"`,
+		},
+		{
+			name:        "missing key",
+			expected:    `{"user": {"phone": "555"}}`,
+			expectedMsg: `JSON does not contain expected value at $.user: missing key "phone"`,
+		},
+		{
+			name:        "different type",
+			expected:    `{"user": ["Alice"]}`,
+			expectedMsg: `JSON does not contain expected value at $.user: expected ["Alice"], got {"email":"alice@example.com","name":"Alice","roles":["admin","dev"]}`,
+		},
+		{
+			name:        "array element",
+			expected:    `{"items": [{"id": 1}, {"qty": 3}]}`,
+			expectedMsg: `JSON does not contain expected value at $.items[1].qty: expected 3, got 1`,
+		},
+		{
+			name:        "array length",
+			expected:    `{"user": {"roles": ["dev"]}}`,
+			expectedMsg: `JSON does not contain expected value at $.user.roles: expected 1 elements, got 2: ["admin","dev"]`,
+		},
+		{
+			name:     "array subset",
+			expected: `{"user": {"roles": ["dev"]}, "items": [{"id": 2}]}`,
+			opts:     []JSONOption{WithArraySubset()},
+		},
+		{
+			name:        "array subset missing element",
+			expected:    `{"user": {"roles": ["dev", "ops"]}}`,
+			opts:        []JSONOption{WithArraySubset()},
+			expectedMsg: `JSON does not contain expected value at $.user.roles: no element matches expected element [1] "ops": ["admin","dev"]`,
+		},
+		{
+			name:        "array subset matches each element once",
+			expected:    `{"user": {"roles": ["dev", "dev"]}}`,
+			opts:        []JSONOption{WithArraySubset()},
+			expectedMsg: `JSON does not contain expected value at $.user.roles: no element matches expected element [1] "dev": ["admin","dev"]`,
+		},
+		{
+			name:        "invalid expected",
+			expected:    `{`,
+			expectedMsg: "failed to parse expected as JSON: unexpected end of JSON input",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result := JSONContains(actual, testcase.expected, testcase.opts...)()
+			if testcase.expectedMsg == "" {
+				assertSuccess(t, result)
+				return
+			}
+			assertFailure(t, result, testcase.expectedMsg)
+		})
+	}
+
+	t.Run("array subset finds a match for every element", func(t *testing.T) {
+		result := JSONContains(`[{"a":1,"b":2},{"a":1}]`, `[{"a":1},{"a":1,"b":2}]`,
+			WithArraySubset())()
+		assertSuccess(t, result)
+	})
+
+	t.Run("large integers are not rounded", func(t *testing.T) {
+		result := JSONContains(`{"id": 9007199254740993}`, `{"id": 9007199254740992}`)()
+		assertFailure(t, result, "JSON does not contain expected value at $.id: "+
+			"expected 9007199254740992, got 9007199254740993")
+	})
+
+	t.Run("invalid actual", func(t *testing.T) {
+		result := JSONContains(`[`, `[]`)()
+		assertFailure(t, result, "failed to parse actual as JSON: unexpected end of JSON input")
+	})
+}
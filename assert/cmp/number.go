@@ -0,0 +1,316 @@
+package cmp
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Greater succeeds if x > y. x and y may be any of the integer or float kinds.
+//
+// Integers of different kinds are compared by value, so an int may be compared
+// to a uint. An integer can not be compared to a float.
+func Greater(x, y interface{}) Comparison {
+	return compareOrder(x, y, "greater than", func(order int) bool {
+		return order > 0
+	})
+}
+
+// GreaterOrEqual succeeds if x >= y. See Greater for the supported types.
+func GreaterOrEqual(x, y interface{}) Comparison {
+	return compareOrder(x, y, "greater than or equal to", func(order int) bool {
+		return order >= 0
+	})
+}
+
+// Less succeeds if x < y. See Greater for the supported types.
+func Less(x, y interface{}) Comparison {
+	return compareOrder(x, y, "less than", func(order int) bool {
+		return order < 0
+	})
+}
+
+// LessOrEqual succeeds if x <= y. See Greater for the supported types.
+func LessOrEqual(x, y interface{}) Comparison {
+	return compareOrder(x, y, "less than or equal to", func(order int) bool {
+		return order <= 0
+	})
+}
+
+func compareOrder(x, y interface{}, relation string, ok func(order int) bool) Comparison {
+	return func() Result {
+		order, err := compareNumbers(x, y)
+		if err != nil {
+			return ResultFailure(err.Error())
+		}
+		return toResult(ok(order), fmt.Sprintf("expected %v to be %s %v", x, relation, y))
+	}
+}
+
+// Between succeeds if low <= x <= high. See Greater for the supported types.
+//
+// Between fails if low is greater than high.
+func Between(x, low, high interface{}) Comparison {
+	return compareRange(x, low, high, "between", func(lowOrder, highOrder int) bool {
+		return lowOrder >= 0 && highOrder <= 0
+	})
+}
+
+// BetweenExclusive succeeds if low < x < high. See Greater for the supported
+// types.
+//
+// BetweenExclusive fails if low is greater than high.
+func BetweenExclusive(x, low, high interface{}) Comparison {
+	return compareRange(x, low, high, "between (exclusive)", func(lowOrder, highOrder int) bool {
+		return lowOrder > 0 && highOrder < 0
+	})
+}
+
+func compareRange(
+	x, low, high interface{},
+	relation string,
+	ok func(lowOrder, highOrder int) bool,
+) Comparison {
+	return func() Result {
+		bounds, err := compareNumbers(low, high)
+		if err != nil {
+			return ResultFailure(err.Error())
+		}
+		if bounds > 0 {
+			return ResultFailure(fmt.Sprintf("invalid range: low %v is greater than high %v", low, high))
+		}
+		lowOrder, err := compareNumbers(x, low)
+		if err != nil {
+			return ResultFailure(err.Error())
+		}
+		highOrder, err := compareNumbers(x, high)
+		if err != nil {
+			return ResultFailure(err.Error())
+		}
+		return toResult(ok(lowOrder, highOrder),
+			fmt.Sprintf("expected %v to be %s %v and %v", x, relation, low, high))
+	}
+}
+
+// Positive succeeds if x is greater than 0. x may be any of the integer or
+// float kinds.
+func Positive(x interface{}) Comparison {
+	return compareSign(x, "positive", func(sign int) bool { return sign > 0 })
+}
+
+// Negative succeeds if x is less than 0. x may be any of the integer or float
+// kinds. A value of an unsigned kind is never negative.
+func Negative(x interface{}) Comparison {
+	return compareSign(x, "negative", func(sign int) bool { return sign < 0 })
+}
+
+// NonZero succeeds if x is not equal to 0. x may be any of the integer or
+// float kinds.
+func NonZero(x interface{}) Comparison {
+	return compareSign(x, "non-zero", func(sign int) bool { return sign != 0 })
+}
+
+func compareSign(x interface{}, description string, ok func(sign int) bool) Comparison {
+	return func() Result {
+		n, err := toNumber(x)
+		if err != nil {
+			return ResultFailure(err.Error())
+		}
+		var sign int
+		switch n.kind {
+		case signedNumber:
+			sign = compareSigned(n.signed, 0)
+		case unsignedNumber:
+			sign = compareUnsigned(n.unsigned, 0)
+		case floatNumber:
+			if math.IsNaN(n.float) {
+				return ResultFailure(fmt.Sprintf("expected NaN to be %s, NaN has no sign", description))
+			}
+			sign = compareFloats(n.float, 0)
+		}
+		return toResult(ok(sign), fmt.Sprintf("expected %v to be %s", x, description))
+	}
+}
+
+type numberKind int
+
+const (
+	signedNumber numberKind = iota
+	unsignedNumber
+	floatNumber
+)
+
+type number struct {
+	kind     numberKind
+	typ      reflect.Type
+	signed   int64
+	unsigned uint64
+	float    float64
+}
+
+func toNumber(v interface{}) (number, error) {
+	value := reflect.ValueOf(v)
+	if !value.IsValid() {
+		return number{}, fmt.Errorf("type %T is not comparable as a number", v)
+	}
+	n := number{typ: value.Type()}
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n.kind, n.signed = signedNumber, value.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		n.kind, n.unsigned = unsignedNumber, value.Uint()
+	case reflect.Float32, reflect.Float64:
+		n.kind, n.float = floatNumber, value.Float()
+	default:
+		return number{}, fmt.Errorf("type %T is not comparable as a number", v)
+	}
+	return n, nil
+}
+
+// compareNumbers returns -1 if x < y, 0 if x == y, and 1 if x > y. An error
+// is returned if either value is not a number, if the values can not be
+// compared, or if either value is NaN.
+func compareNumbers(x, y interface{}) (int, error) {
+	nx, err := toNumber(x)
+	if err != nil {
+		return 0, err
+	}
+	ny, err := toNumber(y)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case nx.kind == floatNumber && ny.kind == floatNumber:
+		if math.IsNaN(nx.float) || math.IsNaN(ny.float) {
+			return 0, fmt.Errorf("can not compare %v and %v, NaN is not ordered", x, y)
+		}
+		return compareFloats(nx.float, ny.float), nil
+	case nx.kind == floatNumber || ny.kind == floatNumber:
+		return 0, fmt.Errorf("can not compare %s and %s", nx.typ, ny.typ)
+	case nx.kind == signedNumber && ny.kind == signedNumber:
+		return compareSigned(nx.signed, ny.signed), nil
+	case nx.kind == unsignedNumber && ny.kind == unsignedNumber:
+		return compareUnsigned(nx.unsigned, ny.unsigned), nil
+	case nx.kind == signedNumber:
+		if nx.signed < 0 {
+			return -1, nil
+		}
+		return compareUnsigned(uint64(nx.signed), ny.unsigned), nil
+	default:
+		if ny.signed < 0 {
+			return 1, nil
+		}
+		return compareUnsigned(nx.unsigned, uint64(ny.signed)), nil
+	}
+}
+
+func compareFloats(x, y float64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+func compareSigned(x, y int64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+func compareUnsigned(x, y uint64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// Close succeeds if the absolute difference between x and y is less than or
+// equal to delta. Close fails if delta is negative or NaN.
+//
+// NaN is never close to any value, including NaN. An infinity is only close to
+// an infinity of the same sign.
+func Close(x, y float64, delta float64) Comparison {
+	return func() Result {
+		if err := checkTolerance("delta", delta); err != nil {
+			return ResultFailure(err.Error())
+		}
+		if result, done := compareSpecialFloats(x, y); done {
+			return result
+		}
+		diff := math.Abs(x - y)
+		return toResult(diff <= delta, fmt.Sprintf(
+			"expected %v and %v to be within %v, difference is %v", x, y, delta, diff))
+	}
+}
+
+// FloatEqual succeeds if x == y, or if both x and y are NaN. Unlike Equal,
+// which uses ==, two NaN values are considered equal, which is useful when
+// NaN is an expected result. NaN is never equal to any number.
+//
+// Positive and negative zero are equal, the same as with ==. Use Equal with
+// math.Signbit to check the sign of a zero.
+func FloatEqual(x, y float64) Comparison {
+	return func() Result {
+		if x == y || math.IsNaN(x) && math.IsNaN(y) {
+			return ResultSuccess
+		}
+		return ResultFailure(fmt.Sprintf("%v (float64) != %v (float64)", x, y))
+	}
+}
+
+// CloseRelative succeeds if the absolute difference between x and y is less
+// than or equal to tolerance multiplied by the larger magnitude of x and y. A
+// tolerance of 0.01 requires that x and y are within 1% of each other.
+// CloseRelative fails if tolerance is negative or NaN.
+//
+// NaN and infinities are handled the same way as Close.
+func CloseRelative(x, y float64, tolerance float64) Comparison {
+	return func() Result {
+		if err := checkTolerance("tolerance", tolerance); err != nil {
+			return ResultFailure(err.Error())
+		}
+		if result, done := compareSpecialFloats(x, y); done {
+			return result
+		}
+		diff := math.Abs(x - y)
+		delta := tolerance * math.Max(math.Abs(x), math.Abs(y))
+		return toResult(diff <= delta, fmt.Sprintf(
+			"expected %v and %v to be within a relative tolerance of %v (%v), difference is %v",
+			x, y, tolerance, delta, diff))
+	}
+}
+
+// checkTolerance returns an error if the tolerance value, which is the argument
+// called name, is negative or NaN.
+func checkTolerance(name string, value float64) error {
+	if value < 0 || math.IsNaN(value) {
+		return fmt.Errorf("invalid %s %v, %s must not be negative", name, value, name)
+	}
+	return nil
+}
+
+// compareSpecialFloats returns a Result and true if either of x or y is NaN or
+// an infinity.
+func compareSpecialFloats(x, y float64) (Result, bool) {
+	switch {
+	case math.IsNaN(x) || math.IsNaN(y):
+		return ResultFailure(fmt.Sprintf(
+			"expected %v and %v to be close, NaN is not close to any value", x, y)), true
+	case math.IsInf(x, 0) || math.IsInf(y, 0):
+		return toResult(x == y, fmt.Sprintf(
+			"expected %v and %v to be close, an infinity is only close to itself", x, y)), true
+	}
+	return nil, false
+}
@@ -0,0 +1,382 @@
+package cmp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOrderComparisons(t *testing.T) {
+	var testcases = []struct {
+		name        string
+		comparison  func(x, y interface{}) Comparison
+		x, y        interface{}
+		expected    bool
+		expectedMsg string
+	}{
+		{
+			name:       "greater int",
+			comparison: Greater,
+			x:          5,
+			y:          3,
+			expected:   true,
+		},
+		{
+			name:        "greater int failure",
+			comparison:  Greater,
+			x:           3,
+			y:           5,
+			expectedMsg: "expected 3 to be greater than 5",
+		},
+		{
+			name:        "greater equal values",
+			comparison:  Greater,
+			x:           uint8(4),
+			y:           uint8(4),
+			expectedMsg: "expected 4 to be greater than 4",
+		},
+		{
+			name:       "greater or equal equal values",
+			comparison: GreaterOrEqual,
+			x:          int64(4),
+			y:          int64(4),
+			expected:   true,
+		},
+		{
+			name:       "less float",
+			comparison: Less,
+			x:          1.5,
+			y:          float32(2.5),
+			expected:   true,
+		},
+		{
+			name:        "less or equal failure",
+			comparison:  LessOrEqual,
+			x:           2.5,
+			y:           1.5,
+			expectedMsg: "expected 2.5 to be less than or equal to 1.5",
+		},
+		{
+			name:       "signed and unsigned",
+			comparison: Less,
+			x:          -1,
+			y:          uint64(math.MaxUint64),
+			expected:   true,
+		},
+		{
+			name:       "unsigned and signed",
+			comparison: Greater,
+			x:          uint(3),
+			y:          int8(-3),
+			expected:   true,
+		},
+		{
+			name:        "int and float",
+			comparison:  Greater,
+			x:           3,
+			y:           2.0,
+			expectedMsg: "can not compare int and float64",
+		},
+		{
+			name:        "not a number",
+			comparison:  Less,
+			x:           "3",
+			y:           4,
+			expectedMsg: "type string is not comparable as a number",
+		},
+		{
+			name:        "nil",
+			comparison:  Less,
+			x:           1,
+			y:           nil,
+			expectedMsg: "type <nil> is not comparable as a number",
+		},
+		{
+			name:        "NaN",
+			comparison:  GreaterOrEqual,
+			x:           math.NaN(),
+			y:           1.0,
+			expectedMsg: "can not compare NaN and 1, NaN is not ordered",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result := testcase.comparison(testcase.x, testcase.y)()
+			if testcase.expected {
+				assertSuccess(t, result)
+			} else {
+				assertFailure(t, result, testcase.expectedMsg)
+			}
+		})
+	}
+}
+
+func TestGreaterWithNamedIntType(t *testing.T) {
+	type count int
+	result := Greater(count(2), 1)()
+	assertSuccess(t, result)
+}
+
+func TestBetween(t *testing.T) {
+	var testcases = []struct {
+		name        string
+		comparison  func(x, low, high interface{}) Comparison
+		x, low, hi  interface{}
+		expected    bool
+		expectedMsg string
+	}{
+		{
+			name:       "inside range",
+			comparison: Between,
+			x:          5,
+			low:        0,
+			hi:         10,
+			expected:   true,
+		},
+		{
+			name:       "equal to bounds",
+			comparison: Between,
+			x:          uint(10),
+			low:        10,
+			hi:         int8(10),
+			expected:   true,
+		},
+		{
+			name:        "above range",
+			comparison:  Between,
+			x:           12,
+			low:         0,
+			hi:          10,
+			expectedMsg: "expected 12 to be between 0 and 10",
+		},
+		{
+			name:       "exclusive inside range",
+			comparison: BetweenExclusive,
+			x:          0.5,
+			low:        0.0,
+			hi:         1.0,
+			expected:   true,
+		},
+		{
+			name:        "exclusive equal to bound",
+			comparison:  BetweenExclusive,
+			x:           0,
+			low:         0,
+			hi:          10,
+			expectedMsg: "expected 0 to be between (exclusive) 0 and 10",
+		},
+		{
+			name:        "low greater than high",
+			comparison:  Between,
+			x:           5,
+			low:         10,
+			hi:          0,
+			expectedMsg: "invalid range: low 10 is greater than high 0",
+		},
+		{
+			name:        "mixed int and float",
+			comparison:  Between,
+			x:           5,
+			low:         0.0,
+			hi:          10.0,
+			expectedMsg: "can not compare int and float64",
+		},
+		{
+			name:        "not a number",
+			comparison:  BetweenExclusive,
+			x:           "five",
+			low:         0,
+			hi:          10,
+			expectedMsg: "type string is not comparable as a number",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := tc.comparison(tc.x, tc.low, tc.hi)()
+			if tc.expected {
+				assertSuccess(t, result)
+				return
+			}
+			assertFailure(t, result, tc.expectedMsg)
+		})
+	}
+}
+
+func TestClose(t *testing.T) {
+	var testcases = []struct {
+		name        string
+		x, y, delta float64
+		expected    bool
+		expectedMsg string
+	}{
+		{
+			name:     "equal",
+			x:        1.5,
+			y:        1.5,
+			expected: true,
+		},
+		{
+			name:     "within delta",
+			x:        0.1 + 0.2,
+			y:        0.3,
+			delta:    1e-9,
+			expected: true,
+		},
+		{
+			name:     "difference equals delta",
+			x:        1,
+			y:        1.5,
+			delta:    0.5,
+			expected: true,
+		},
+		{
+			name:        "outside delta",
+			x:           1,
+			y:           1.5,
+			delta:       0.25,
+			expectedMsg: "expected 1 and 1.5 to be within 0.25, difference is 0.5",
+		},
+		{
+			name:        "NaN",
+			x:           math.NaN(),
+			y:           math.NaN(),
+			delta:       1,
+			expectedMsg: "expected NaN and NaN to be close, NaN is not close to any value",
+		},
+		{
+			name:     "same infinity",
+			x:        math.Inf(1),
+			y:        math.Inf(1),
+			expected: true,
+		},
+		{
+			name:  "different infinity",
+			x:     math.Inf(1),
+			y:     math.Inf(-1),
+			delta: math.Inf(1),
+			expectedMsg: "expected +Inf and -Inf to be close, " +
+				"an infinity is only close to itself",
+		},
+		{
+			name:  "infinity and finite",
+			x:     math.Inf(-1),
+			y:     1,
+			delta: math.Inf(1),
+			expectedMsg: "expected -Inf and 1 to be close, " +
+				"an infinity is only close to itself",
+		},
+		{
+			name:        "negative delta",
+			x:           1,
+			y:           1,
+			delta:       -1,
+			expectedMsg: "invalid delta -1, delta must not be negative",
+		},
+		{
+			name:        "NaN delta",
+			x:           1,
+			y:           1,
+			delta:       math.NaN(),
+			expectedMsg: "invalid delta NaN, delta must not be negative",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result := Close(testcase.x, testcase.y, testcase.delta)()
+			if testcase.expected {
+				assertSuccess(t, result)
+			} else {
+				assertFailure(t, result, testcase.expectedMsg)
+			}
+		})
+	}
+}
+
+func TestFloatEqual(t *testing.T) {
+	assertSuccess(t, FloatEqual(1.5, 1.5)())
+	assertSuccess(t, FloatEqual(math.NaN(), math.NaN())())
+	assertSuccess(t, FloatEqual(0, math.Copysign(0, -1))())
+	assertSuccess(t, FloatEqual(math.Inf(1), math.Inf(1))())
+
+	assertFailure(t, FloatEqual(1.5, 2.5)(), "1.5 (float64) != 2.5 (float64)")
+	assertFailure(t, FloatEqual(math.NaN(), 1)(), "NaN (float64) != 1 (float64)")
+	assertFailure(t, FloatEqual(math.Inf(1), math.Inf(-1))(), "+Inf (float64) != -Inf (float64)")
+}
+
+func TestCloseRelative(t *testing.T) {
+	result := CloseRelative(1000000, 1000500, 0.001)()
+	assertSuccess(t, result)
+
+	result = CloseRelative(100, 110, 0.05)()
+	assertFailure(t, result,
+		"expected 100 and 110 to be within a relative tolerance of 0.05 (5.5), difference is 10")
+
+	result = CloseRelative(math.NaN(), 1, 1)()
+	assertFailure(t, result,
+		"expected NaN and 1 to be close, NaN is not close to any value")
+
+	result = CloseRelative(1, 1, -0.1)()
+	assertFailure(t, result, "invalid tolerance -0.1, tolerance must not be negative")
+}
+
+func TestSignComparisons(t *testing.T) {
+	var testcases = []struct {
+		name        string
+		comparison  func(x interface{}) Comparison
+		x           interface{}
+		expectedMsg string
+	}{
+		{name: "positive int", comparison: Positive, x: 3},
+		{name: "positive uint", comparison: Positive, x: uint16(1)},
+		{name: "positive float", comparison: Positive, x: float32(0.1)},
+		{
+			name:        "negative is not positive",
+			comparison:  Positive,
+			x:           -3,
+			expectedMsg: "expected -3 to be positive",
+		},
+		{
+			name:        "zero is not positive",
+			comparison:  Positive,
+			x:           uint(0),
+			expectedMsg: "expected 0 to be positive",
+		},
+		{name: "negative int", comparison: Negative, x: int32(-1)},
+		{name: "negative float", comparison: Negative, x: -0.5},
+		{
+			name:        "unsigned is never negative",
+			comparison:  Negative,
+			x:           uint64(math.MaxUint64),
+			expectedMsg: "expected 18446744073709551615 to be negative",
+		},
+		{name: "non-zero int", comparison: NonZero, x: int8(-1)},
+		{name: "non-zero uint", comparison: NonZero, x: uint8(1)},
+		{
+			name:        "zero float",
+			comparison:  NonZero,
+			x:           0.0,
+			expectedMsg: "expected 0 to be non-zero",
+		},
+		{
+			name:        "NaN",
+			comparison:  Positive,
+			x:           math.NaN(),
+			expectedMsg: "expected NaN to be positive, NaN has no sign",
+		},
+		{
+			name:        "not a number",
+			comparison:  NonZero,
+			x:           "1",
+			expectedMsg: "type string is not comparable as a number",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result := testcase.comparison(testcase.x)()
+			if testcase.expectedMsg == "" {
+				assertSuccess(t, result)
+			} else {
+				assertFailure(t, result, testcase.expectedMsg)
+			}
+		})
+	}
+}
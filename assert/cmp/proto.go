@@ -0,0 +1,49 @@
+package cmp
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// protoMessage is the proto.Message interface from github.com/golang/protobuf,
+// which is implemented by all messages generated by protoc-gen-go.
+type protoMessage interface {
+	Reset()
+	String() string
+	ProtoMessage()
+}
+
+// ProtoEqual compares two protobuf messages using DeepEqual, and succeeds if
+// the messages are equal. x and y must be messages generated by protoc-gen-go.
+//
+// ProtoEqual does not depend on the protobuf module. Instead the fields of the
+// generated structs are compared, ignoring unexported fields and fields with an
+// XXX_ prefix, which hold the internal state of the message. Unknown fields are
+// not compared. Use DeepEqual with protocmp.Transform() from
+// google.golang.org/protobuf/testing/protocmp to compare messages using the
+// full protobuf semantics.
+func ProtoEqual(x, y interface{}) Comparison {
+	return func() Result {
+		if _, ok := x.(protoMessage); !ok {
+			return ResultFailure(fmt.Sprintf("x (%T) is not a protobuf message", x))
+		}
+		if _, ok := y.(protoMessage); !ok {
+			return ResultFailure(fmt.Sprintf("y (%T) is not a protobuf message", y))
+		}
+		return DeepEqual(x, y, ignoreProtoInternalFields)()
+	}
+}
+
+var ignoreProtoInternalFields = cmp.FilterPath(func(path cmp.Path) bool {
+	field, ok := path.Last().(cmp.StructField)
+	if !ok {
+		return false
+	}
+	name := field.Name()
+	first, _ := utf8.DecodeRuneInString(name)
+	return !unicode.IsUpper(first) || strings.HasPrefix(name, "XXX_")
+}, cmp.Ignore())
@@ -0,0 +1,53 @@
+package cmp
+
+import (
+	"strings"
+	"testing"
+)
+
+// stubMessage has the same shape as a message generated by protoc-gen-go.
+type stubMessage struct {
+	state         struct{ initialized bool }
+	sizeCache     int32
+	unknownFields []byte
+
+	Name     string
+	Children []*stubMessage
+
+	XXX_sizecache int32
+}
+
+func (m *stubMessage) Reset()         { *m = stubMessage{} }
+func (m *stubMessage) String() string { return m.Name }
+func (*stubMessage) ProtoMessage()    {}
+
+func TestProtoEqual(t *testing.T) {
+	t.Run("ignores internal fields", func(t *testing.T) {
+		x := &stubMessage{sizeCache: 12, Name: "a", Children: []*stubMessage{{Name: "b"}}}
+		y := &stubMessage{XXX_sizecache: 3, Name: "a", Children: []*stubMessage{{
+			Name:          "b",
+			unknownFields: []byte{1},
+		}}}
+		assertSuccess(t, ProtoEqual(x, y)())
+	})
+
+	t.Run("different messages", func(t *testing.T) {
+		x := &stubMessage{Name: "a", Children: []*stubMessage{{Name: "b"}}}
+		y := &stubMessage{Name: "a", Children: []*stubMessage{{Name: "c"}}}
+		result := ProtoEqual(x, y)()
+		if result.Success() {
+			t.Fatalf("expected failure")
+		}
+		msg := result.(templatedResult).FailureMessage(nil)
+		if !strings.Contains(msg, `"b"`) || !strings.Contains(msg, `"c"`) {
+			t.Errorf("expected diff of the names, got\n%s", msg)
+		}
+	})
+
+	t.Run("not a message", func(t *testing.T) {
+		assertFailure(t, ProtoEqual(stubMessage{}, &stubMessage{})(),
+			"x (cmp.stubMessage) is not a protobuf message")
+		assertFailure(t, ProtoEqual(&stubMessage{}, nil)(),
+			"y (<nil>) is not a protobuf message")
+	})
+}
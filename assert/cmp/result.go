@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"go/ast"
+	"reflect"
+	"sync"
 	"text/template"
 
 	"gotest.tools/v3/internal/source"
@@ -19,16 +21,29 @@ type Result interface {
 // message.
 type StringResult struct {
 	success bool
+	warning bool
 	message string
 }
 
-// Success returns true if the comparison was successful.
+// Success returns true if the comparison was successful. A warning is also
+// successful.
 func (r StringResult) Success() bool {
 	return r.success
 }
 
+// Severity returns the Severity of the Result.
+func (r StringResult) Severity() Severity {
+	switch {
+	case r.warning:
+		return SeverityWarning
+	case r.success:
+		return SeveritySuccess
+	}
+	return SeverityFailure
+}
+
 // FailureMessage returns the message used to provide additional information
-// about the failure.
+// about the failure, or the message of a warning.
 func (r StringResult) FailureMessage() string {
 	return r.message
 }
@@ -42,6 +57,54 @@ func ResultFailure(message string) StringResult {
 	return StringResult{message: message}
 }
 
+// ResultWarning returns a successful Result with a warning message. The assert
+// package logs the message of a warning, but the test does not fail.
+//
+// A warning can be used to introduce a new assertion without failing existing
+// tests. Once the warning is no longer logged, the comparison can be changed to
+// return ResultFailure.
+func ResultWarning(message string) StringResult {
+	return StringResult{success: true, warning: true, message: message}
+}
+
+// Severity of a Result.
+type Severity int
+
+const (
+	// SeveritySuccess is the Severity of a successful Result.
+	SeveritySuccess Severity = iota
+	// SeverityWarning is the Severity of a Result created by ResultWarning.
+	SeverityWarning
+	// SeverityFailure is the Severity of a failed Result.
+	SeverityFailure
+)
+
+// ResultSeverity returns the Severity of result. If result does not have a
+// Severity method the Severity is SeveritySuccess or SeverityFailure,
+// depending on the value of Success.
+func ResultSeverity(result Result) Severity {
+	if typed, ok := result.(interface{ Severity() Severity }); ok {
+		return typed.Severity()
+	}
+	if result.Success() {
+		return SeveritySuccess
+	}
+	return SeverityFailure
+}
+
+func isWarning(result Result) bool {
+	return ResultSeverity(result) == SeverityWarning
+}
+
+// resultWithMessage returns a warning with message msg if result is a warning,
+// and otherwise a failure with message msg.
+func resultWithMessage(result Result, msg string) Result {
+	if isWarning(result) {
+		return ResultWarning(msg)
+	}
+	return ResultFailure(msg)
+}
+
 // ResultFromError returns ResultSuccess if err is nil. Otherwise ResultFailure
 // is returned with the error message as the failure message.
 func ResultFromError(err error) Result {
@@ -51,6 +114,45 @@ func ResultFromError(err error) Result {
 	return ResultFailure(err.Error())
 }
 
+// Run calls the Comparison c and returns true if it succeeded. If the
+// comparison failed the failure message is also returned. If the comparison
+// succeeded with a warning from ResultWarning, Run returns true and the message
+// of the warning. Use ResultSeverity to check the Severity of a Result.
+//
+// Run can be used to evaluate a Comparison without a testing.T. Messages from
+// a Result created with ResultFailureTemplate are rendered when Run is called,
+// but without the source of the arguments used to create the Comparison.
+func Run(c Comparison) (success bool, message string) {
+	result := c()
+	switch {
+	case isWarning(result):
+		return true, failureMessage(result, nil)
+	case result.Success():
+		return true, ""
+	}
+	return false, failureMessage(result, nil)
+}
+
+type resultWithComparisonArgs interface {
+	FailureMessage(args []ast.Expr) string
+}
+
+type resultBasic interface {
+	FailureMessage() string
+}
+
+// failureMessage returns the failure message of result. args are the source of
+// the arguments to the Comparison, which are used by templated results.
+func failureMessage(result Result, args []ast.Expr) string {
+	switch typed := result.(type) {
+	case resultWithComparisonArgs:
+		return typed.FailureMessage(args)
+	case resultBasic:
+		return typed.FailureMessage()
+	}
+	return fmt.Sprintf("comparison returned invalid Result type: %T", result)
+}
+
 type templatedResult struct {
 	success  bool
 	template string
@@ -72,14 +174,17 @@ func (r templatedResult) FailureMessage(args []ast.Expr) string {
 // ResultFailureTemplate returns a Result with a template string and data which
 // can be used to format a failure message. The template may access data from .Data,
 // the comparison args with the callArg function, and the formatNode function may
-// be used to format the call args.
+// be used to format the call args. The formatValue function formats a value
+// using a formatter from RegisterFormatter, or with %v if there is no formatter
+// for the type of the value.
 func ResultFailureTemplate(template string, data map[string]interface{}) Result {
 	return templatedResult{template: template, data: data}
 }
 
 func renderMessage(result templatedResult, args []ast.Expr) (string, error) {
 	tmpl := template.New("failure").Funcs(template.FuncMap{
-		"formatNode": source.FormatNode,
+		"formatNode":  source.FormatNode,
+		"formatValue": formatValue,
 		"callArg": func(index int) ast.Expr {
 			if index >= len(args) {
 				return nil
@@ -98,3 +203,41 @@ func renderMessage(result templatedResult, args []ast.Expr) (string, error) {
 	})
 	return buf.String(), err
 }
+
+var formatters = struct {
+	sync.RWMutex
+	byType map[reflect.Type]func(interface{}) string
+}{byType: make(map[reflect.Type]func(interface{}) string)}
+
+// RegisterFormatter sets the function used to format values of type typ in
+// the failure message of Equal. Registering a nil function removes the
+// formatter for typ.
+//
+// Only Equal, and custom comparisons which print values with the formatValue
+// template function of ResultFailureTemplate, use the formatters. Other
+// comparisons, including the diffs printed by DeepEqual, format values
+// without them. RegisterFormatter is safe to call from multiple goroutines.
+//
+// Example:
+//   cmp.RegisterFormatter(reflect.TypeOf(time.Time{}), func(v interface{}) string {
+//       return v.(time.Time).Format(time.RFC3339Nano)
+//   })
+func RegisterFormatter(typ reflect.Type, formatter func(interface{}) string) {
+	formatters.Lock()
+	defer formatters.Unlock()
+	if formatter == nil {
+		delete(formatters.byType, typ)
+		return
+	}
+	formatters.byType[typ] = formatter
+}
+
+func formatValue(value interface{}) string {
+	formatters.RLock()
+	formatter, ok := formatters.byType[reflect.TypeOf(value)]
+	formatters.RUnlock()
+	if ok {
+		return formatter(value)
+	}
+	return fmt.Sprintf("%v", value)
+}
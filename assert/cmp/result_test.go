@@ -0,0 +1,186 @@
+package cmp
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type stubResult struct{}
+
+func (stubResult) Success() bool {
+	return false
+}
+
+func TestRun(t *testing.T) {
+	var testcases = []struct {
+		name            string
+		comparison      Comparison
+		expectedSuccess bool
+		expectedMessage string
+	}{
+		{
+			name:            "success",
+			comparison:      Equal(1, 1),
+			expectedSuccess: true,
+		},
+		{
+			name:            "failure",
+			comparison:      Contains("abc", "d"),
+			expectedMessage: `string "abc" does not contain "d"`,
+		},
+		{
+			name:            "templated failure",
+			comparison:      Equal(1, 2),
+			expectedMessage: "1 (int) != 2 (int)",
+		},
+		{
+			name:            "warning",
+			comparison:      func() Result { return ResultWarning("almost") },
+			expectedSuccess: true,
+			expectedMessage: "almost",
+		},
+		{
+			name:            "invalid result",
+			comparison:      func() Result { return stubResult{} },
+			expectedMessage: "comparison returned invalid Result type: cmp.stubResult",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			success, message := Run(testcase.comparison)
+			if success != testcase.expectedSuccess {
+				t.Errorf("expected success=%v, got %v", testcase.expectedSuccess, success)
+			}
+			if message != testcase.expectedMessage {
+				t.Errorf("expected message %q, got %q", testcase.expectedMessage, message)
+			}
+		})
+	}
+}
+
+func TestCombinatorsPropagateWarnings(t *testing.T) {
+	warning := func() Result { return ResultWarning("almost") }
+	var testcases = []struct {
+		name       string
+		comparison Comparison
+		expected   string
+	}{
+		{name: "And", comparison: And(Equal(1, 1), warning, warning), expected: "almost"},
+		{name: "Or", comparison: Or(Equal(1, 2), warning), expected: "almost"},
+		{
+			name: "Each",
+			comparison: Each([]int{1, 2}, func(i int, _ interface{}) Comparison {
+				if i == 1 {
+					return warning
+				}
+				return Equal(1, 1)
+			}),
+			expected: "[1]: almost",
+		},
+		{name: "WithLabel", comparison: WithLabel("count", warning), expected: "count: almost"},
+		{
+			name:       "Annotated",
+			comparison: Annotated(warning, "record %d", 3),
+			expected:   "almost: record 3",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result := testcase.comparison()
+			if severity := ResultSeverity(result); severity != SeverityWarning {
+				t.Fatalf("expected SeverityWarning, got %v", severity)
+			}
+			assertSuccess(t, result)
+			if msg := result.(StringResult).FailureMessage(); msg != testcase.expected {
+				t.Errorf("expected message %q, got %q", testcase.expected, msg)
+			}
+		})
+	}
+
+	t.Run("Timed", func(t *testing.T) {
+		result := Timed(warning)()
+		if severity := ResultSeverity(result); severity != SeverityWarning {
+			t.Fatalf("expected SeverityWarning, got %v", severity)
+		}
+		msg := result.(StringResult).FailureMessage()
+		if !strings.HasPrefix(msg, "almost (comparison took ") {
+			t.Errorf("unexpected message %q", msg)
+		}
+	})
+
+	t.Run("And failure after a warning", func(t *testing.T) {
+		assertFailure(t, And(warning, Contains("abc", "d"))(), `string "abc" does not contain "d"`)
+	})
+
+	t.Run("Each failure after a warning", func(t *testing.T) {
+		result := Each([]int{1, 2}, func(i int, _ interface{}) Comparison {
+			if i == 0 {
+				return warning
+			}
+			return Equal(1, 2)
+		})()
+		assertFailure(t, result, "1 of 2 elements failed:\n  [1]: 1 (int) != 2 (int)")
+	})
+
+	t.Run("Not", func(t *testing.T) {
+		result := Not(warning)()
+		assertFailureTemplate(t, result, nil,
+			"expected comparison to fail, but it succeeded with a warning: almost")
+	})
+}
+
+type celsius float64
+
+func TestResultSeverity(t *testing.T) {
+	if severity := ResultSeverity(ResultSuccess); severity != SeveritySuccess {
+		t.Errorf("expected ResultSuccess to have SeveritySuccess, got %v", severity)
+	}
+	if severity := ResultSeverity(ResultFailure("oops")); severity != SeverityFailure {
+		t.Errorf("expected ResultFailure to have SeverityFailure, got %v", severity)
+	}
+	if severity := ResultSeverity(Equal(1, 2)()); severity != SeverityFailure {
+		t.Errorf("expected templated failure to have SeverityFailure, got %v", severity)
+	}
+
+	warning := ResultWarning("almost")
+	if severity := ResultSeverity(warning); severity != SeverityWarning {
+		t.Errorf("expected ResultWarning to have SeverityWarning, got %v", severity)
+	}
+	assertSuccess(t, warning)
+	if msg := warning.FailureMessage(); msg != "almost" {
+		t.Errorf("expected warning message, got %q", msg)
+	}
+}
+
+func TestRegisterFormatter(t *testing.T) {
+	typ := reflect.TypeOf(celsius(0))
+	RegisterFormatter(typ, func(v interface{}) string {
+		return fmt.Sprintf("%.1f°C", v)
+	})
+	defer RegisterFormatter(typ, nil)
+
+	result := Equal(celsius(21.5), celsius(19))()
+	assertFailureTemplate(t, result, nil, "21.5°C (cmp.celsius) != 19.0°C (cmp.celsius)")
+
+	result = Equal(21.5, 19.0)()
+	assertFailureTemplate(t, result, nil, "21.5 (float64) != 19 (float64)")
+}
+
+func TestRegisterFormatterConcurrently(t *testing.T) {
+	typ := reflect.TypeOf(celsius(0))
+	defer RegisterFormatter(typ, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			RegisterFormatter(typ, func(v interface{}) string { return "formatted" })
+			Run(Equal(celsius(1), celsius(2)))
+		}()
+	}
+	wg.Wait()
+}
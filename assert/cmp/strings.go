@@ -0,0 +1,189 @@
+package cmp
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gotest.tools/v3/internal/format"
+)
+
+// ContainsFold succeeds if item is a substring of s, ignoring case. See
+// Contains for a case-sensitive comparison.
+func ContainsFold(s, item string) Comparison {
+	return func() Result {
+		return toResult(
+			strings.Contains(strings.ToLower(s), strings.ToLower(item)),
+			fmt.Sprintf("string %q does not contain %q (case-insensitive)", s, item))
+	}
+}
+
+// EqualFold succeeds if x and y are equal when compared using
+// strings.EqualFold, which ignores case. See Equal for a case-sensitive
+// comparison.
+func EqualFold(x, y string) Comparison {
+	return func() Result {
+		return toResult(
+			strings.EqualFold(x, y),
+			fmt.Sprintf("%q != %q (case-insensitive)", x, y))
+	}
+}
+
+// HasPrefix succeeds if s starts with prefix.
+func HasPrefix(s, prefix string) Comparison {
+	return func() Result {
+		return toResult(
+			strings.HasPrefix(s, prefix),
+			fmt.Sprintf("%q does not have prefix %q", s, prefix))
+	}
+}
+
+// HasSuffix succeeds if s ends with suffix.
+func HasSuffix(s, suffix string) Comparison {
+	return func() Result {
+		return toResult(
+			strings.HasSuffix(s, suffix),
+			fmt.Sprintf("%q does not have suffix %q", s, suffix))
+	}
+}
+
+// MultiLineOption is an option for EqualMultiLine.
+type MultiLineOption func(*multiLineConfig)
+
+type multiLineConfig struct {
+	context  int
+	from, to string
+}
+
+// WithContext sets the number of unchanged lines shown around each change in
+// the diff. A value of 0 shows only the lines which changed.
+func WithContext(n int) MultiLineOption {
+	return func(conf *multiLineConfig) {
+		if n == 0 {
+			n = -1
+		}
+		conf.context = n
+	}
+}
+
+// WithNames sets the labels used for x and y in the header of the diff. By
+// default the source of the arguments to EqualMultiLine are used, which is
+// also the label used when from or to is empty.
+func WithNames(from, to string) MultiLineOption {
+	return func(conf *multiLineConfig) {
+		conf.from, conf.to = from, to
+	}
+}
+
+// EqualMultiLine succeeds if x == y. If the strings are not equal the failure
+// message is a unified diff of the two strings, which can be customized with
+// WithContext and WithNames.
+func EqualMultiLine(x, y string, opts ...MultiLineOption) Comparison {
+	return func() Result {
+		if x == y {
+			return ResultSuccess
+		}
+		conf := &multiLineConfig{}
+		for _, opt := range opts {
+			opt(conf)
+		}
+		diff := format.UnifiedDiff(format.DiffConfig{A: x, B: y, Context: conf.context})
+		return namedDiffResult(diff, conf.from, conf.to)
+	}
+}
+
+// namedDiffResult is like multiLineDiffResult, but uses from and to as the
+// labels in the header of the diff. An empty name falls back to the default
+// label.
+func namedDiffResult(diff, from, to string) Result {
+	return ResultFailureTemplate(`
+--- {{ with .Data.from }}{{ . }}{{ else }}{{ with callArg 0 }}{{ formatNode . }}{{else}}←{{end}}{{ end }}
++++ {{ with .Data.to }}{{ . }}{{ else }}{{ with callArg 1 }}{{ formatNode . }}{{else}}→{{end}}{{ end }}
+{{ .Data.diff }}`,
+		map[string]interface{}{"diff": colorDiff(diff), "from": from, "to": to})
+}
+
+// StringSliceOption is an option for StringSliceEqual.
+type StringSliceOption func(*stringSliceConfig)
+
+type stringSliceConfig struct {
+	sort, trim, fold bool
+}
+
+// WithSort sorts both slices before they are compared, so that the order of
+// the elements is ignored.
+func WithSort() StringSliceOption {
+	return func(conf *stringSliceConfig) {
+		conf.sort = true
+	}
+}
+
+// WithTrim removes leading and trailing whitespace from each element before
+// the elements are compared.
+func WithTrim() StringSliceOption {
+	return func(conf *stringSliceConfig) {
+		conf.trim = true
+	}
+}
+
+// WithFold converts each element to lower case before the elements are
+// compared, so that case is ignored.
+func WithFold() StringSliceOption {
+	return func(conf *stringSliceConfig) {
+		conf.fold = true
+	}
+}
+
+// StringSliceEqual succeeds if x and y have the same elements in the same
+// order, after each element is normalized using opts. Without any options
+// the elements must be exactly equal.
+//
+// The failure message shows both normalized slices, and the elements which
+// are only in one of the slices.
+//
+// Example:
+//   assert.Assert(t, cmp.StringSliceEqual(tags, []string{"api", "v2"},
+//       cmp.WithSort(), cmp.WithTrim(), cmp.WithFold()))
+func StringSliceEqual(x, y []string, opts ...StringSliceOption) Comparison {
+	return func() Result {
+		conf := &stringSliceConfig{}
+		for _, opt := range opts {
+			opt(conf)
+		}
+		nx, ny := conf.normalize(x), conf.normalize(y)
+		if reflect.DeepEqual(nx, ny) {
+			return ResultSuccess
+		}
+
+		msg := fmt.Sprintf("%q != %q", nx, ny)
+		onlyX, onlyY := diffElements(reflect.ValueOf(nx), reflect.ValueOf(ny))
+		if len(onlyX) > 0 {
+			msg += fmt.Sprintf("\nonly in x: %q", onlyX)
+		}
+		if len(onlyY) > 0 {
+			msg += fmt.Sprintf("\nonly in y: %q", onlyY)
+		}
+		if len(onlyX) == 0 && len(onlyY) == 0 {
+			msg += "\nthe elements are in a different order"
+		}
+		return ResultFailure(msg)
+	}
+}
+
+func (conf *stringSliceConfig) normalize(seq []string) []string {
+	normalized := make([]string, len(seq))
+	for i, item := range seq {
+		if conf.trim {
+			item = strings.TrimSpace(item)
+		}
+		if conf.fold {
+			item = strings.ToLower(item)
+		}
+		normalized[i] = item
+	}
+	if conf.sort {
+		sort.Strings(normalized)
+	}
+	return normalized
+}
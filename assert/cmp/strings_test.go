@@ -0,0 +1,171 @@
+package cmp
+
+import (
+	"go/ast"
+	"testing"
+)
+
+func TestContainsFold(t *testing.T) {
+	result := ContainsFold("Hello World", "WORLD")()
+	assertSuccess(t, result)
+
+	result = ContainsFold("Hello", "")()
+	assertSuccess(t, result)
+
+	result = ContainsFold("Hello", "WORLD")()
+	assertFailure(t, result, `string "Hello" does not contain "WORLD" (case-insensitive)`)
+}
+
+func TestEqualFold(t *testing.T) {
+	result := EqualFold("Example.COM", "example.com")()
+	assertSuccess(t, result)
+
+	result = EqualFold("example.com", "example.org")()
+	assertFailure(t, result, `"example.com" != "example.org" (case-insensitive)`)
+}
+
+func TestHasPrefix(t *testing.T) {
+	result := HasPrefix("INFO starting server", "INFO ")()
+	assertSuccess(t, result)
+
+	result = HasPrefix("WARN starting server", "INFO ")()
+	assertFailure(t, result, `"WARN starting server" does not have prefix "INFO "`)
+}
+
+func TestHasSuffix(t *testing.T) {
+	result := HasSuffix("report.tmp", ".tmp")()
+	assertSuccess(t, result)
+
+	result = HasSuffix("report.txt", ".csv")()
+	assertFailure(t, result, `"report.txt" does not have suffix ".csv"`)
+}
+
+func TestEqualMultiLineWithOptions(t *testing.T) {
+	x := "1\n2\n3\n4\n5\n6\n7\n"
+	y := "1\n2\n3\nfour\n5\n6\n7\n"
+	args := []ast.Expr{&ast.Ident{Name: "actual"}, &ast.Ident{Name: "expected"}}
+
+	t.Run("equal", func(t *testing.T) {
+		assertSuccess(t, EqualMultiLine(x, x)())
+	})
+
+	t.Run("no options", func(t *testing.T) {
+		result := EqualMultiLine(x, y)()
+		expected := `
+--- actual
++++ expected
+@@ -2,5 +2,5 @@
+ 2
+ 3
+-4
++four
+ 5
+ 6
+`
+		assertFailureTemplate(t, result, args, expected)
+	})
+
+	t.Run("with context", func(t *testing.T) {
+		result := EqualMultiLine(x, y, WithContext(0))()
+		expected := `
+--- actual
++++ expected
+@@ -4 +4 @@
+-4
++four
+`
+		assertFailureTemplate(t, result, args, expected)
+	})
+
+	t.Run("with names", func(t *testing.T) {
+		result := EqualMultiLine(x, y, WithNames("config.old", "config.new"), WithContext(1))()
+		expected := `
+--- config.old
++++ config.new
+@@ -3,3 +3,3 @@
+ 3
+-4
++four
+ 5
+`
+		assertFailureTemplate(t, result, nil, expected)
+	})
+
+	t.Run("with an empty name", func(t *testing.T) {
+		result := EqualMultiLine(x, y, WithNames("config.old", ""), WithContext(1))()
+		args := []ast.Expr{&ast.Ident{Name: "old"}, &ast.Ident{Name: "updated"}}
+		expected := `
+--- config.old
++++ updated
+@@ -3,3 +3,3 @@
+ 3
+-4
++four
+ 5
+`
+		assertFailureTemplate(t, result, args, expected)
+	})
+}
+
+func TestStringSliceEqual(t *testing.T) {
+	var testcases = []struct {
+		name        string
+		x, y        []string
+		opts        []StringSliceOption
+		expectedMsg string
+	}{
+		{
+			name: "equal",
+			x:    []string{"a", "b"},
+			y:    []string{"a", "b"},
+		},
+		{
+			name: "nil and empty",
+			x:    nil,
+			y:    []string{},
+		},
+		{
+			name:        "different order",
+			x:           []string{"a", "b"},
+			y:           []string{"b", "a"},
+			expectedMsg: "[\"a\" \"b\"] != [\"b\" \"a\"]\nthe elements are in a different order",
+		},
+		{
+			name: "sorted",
+			x:    []string{"a", "b"},
+			y:    []string{"b", "a"},
+			opts: []StringSliceOption{WithSort()},
+		},
+		{
+			name: "normalized",
+			x:    []string{" API", "v2 "},
+			y:    []string{"v2", "api"},
+			opts: []StringSliceOption{WithSort(), WithTrim(), WithFold()},
+		},
+		{
+			name: "extra and missing",
+			x:    []string{"API ", "v1"},
+			y:    []string{"api", "v2", "beta"},
+			opts: []StringSliceOption{WithTrim(), WithFold()},
+			expectedMsg: "[\"api\" \"v1\"] != [\"api\" \"v2\" \"beta\"]\n" +
+				"only in x: [\"v1\"]\nonly in y: [\"v2\" \"beta\"]",
+		},
+		{
+			name:        "not trimmed",
+			x:           []string{"a "},
+			y:           []string{"a"},
+			opts:        []StringSliceOption{WithFold()},
+			expectedMsg: "[\"a \"] != [\"a\"]\nonly in x: [\"a \"]\nonly in y: [\"a\"]",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result := StringSliceEqual(testcase.x, testcase.y, testcase.opts...)()
+			if testcase.expectedMsg == "" {
+				assertSuccess(t, result)
+				return
+			}
+			assertFailure(t, result, testcase.expectedMsg)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package cmp
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeEqual succeeds if x and y are the same instant in time, or if the
+// difference between them is less than or equal to tolerance. The times are
+// compared using Sub, so differences in location and monotonic clock readings
+// are ignored. A tolerance of 0 requires that both times are the same instant.
+func TimeEqual(x, y time.Time, tolerance time.Duration) Comparison {
+	return func() Result {
+		delta := x.Sub(y)
+		if delta < 0 {
+			delta = -delta
+		}
+		return toResult(delta <= tolerance, fmt.Sprintf(
+			"expected %s and %s to be within %s, difference is %s",
+			x.Format(time.RFC3339Nano), y.Format(time.RFC3339Nano), tolerance, delta))
+	}
+}
@@ -0,0 +1,39 @@
+package cmp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeEqual(t *testing.T) {
+	base := time.Date(2019, 6, 1, 12, 30, 0, 0, time.UTC)
+
+	t.Run("same instant in a different location", func(t *testing.T) {
+		other := base.In(time.FixedZone("EST", -5*60*60))
+		result := TimeEqual(base, other, 0)()
+		assertSuccess(t, result)
+	})
+
+	t.Run("monotonic clock reading is ignored", func(t *testing.T) {
+		now := time.Now()
+		result := TimeEqual(now, now.Round(0), 0)()
+		assertSuccess(t, result)
+	})
+
+	t.Run("within tolerance", func(t *testing.T) {
+		result := TimeEqual(base, base.Add(-time.Second), time.Second)()
+		assertSuccess(t, result)
+	})
+
+	t.Run("outside tolerance", func(t *testing.T) {
+		result := TimeEqual(base, base.Add(1500*time.Millisecond), time.Second)()
+		assertFailure(t, result, "expected 2019-06-01T12:30:00Z and "+
+			"2019-06-01T12:30:01.5Z to be within 1s, difference is 1.5s")
+	})
+
+	t.Run("zero tolerance", func(t *testing.T) {
+		result := TimeEqual(base.Add(time.Nanosecond), base, 0)()
+		assertFailure(t, result, "expected 2019-06-01T12:30:00.000000001Z and "+
+			"2019-06-01T12:30:00Z to be within 0s, difference is 1ns")
+	})
+}
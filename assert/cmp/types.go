@@ -0,0 +1,122 @@
+package cmp
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Implements succeeds if the type of x implements an interface. ifacePtr must
+// be a pointer to the interface type.
+//
+// Example:
+//   assert.Assert(t, cmp.Implements(value, (*io.Reader)(nil)))
+func Implements(x interface{}, ifacePtr interface{}) Comparison {
+	return func() Result {
+		ifaceType := reflect.TypeOf(ifacePtr)
+		if ifaceType == nil || !isPtrToInterface(ifaceType) {
+			return ResultFailure(fmt.Sprintf(
+				"invalid type %T for interface, expected a pointer to an interface, "+
+					"for example (*io.Reader)(nil)", ifacePtr))
+		}
+		ifaceType = ifaceType.Elem()
+		if x == nil {
+			return ResultFailure(fmt.Sprintf("nil does not implement %s", ifaceType))
+		}
+		return toResult(reflect.TypeOf(x).Implements(ifaceType),
+			fmt.Sprintf("%T does not implement %s", x, ifaceType))
+	}
+}
+
+// SameType succeeds if x and y have the same dynamic type.
+func SameType(x, y interface{}) Comparison {
+	return func() Result {
+		if x == nil || y == nil {
+			return ResultFailure(fmt.Sprintf("nil has no type, got %T and %T", x, y))
+		}
+		return toResult(reflect.TypeOf(x) == reflect.TypeOf(y),
+			fmt.Sprintf("type %T is not the same as type %T", x, y))
+	}
+}
+
+// KindOf succeeds if the dynamic type of x is of the expected kind.
+//
+// Example:
+//   assert.Assert(t, cmp.KindOf(decoded, reflect.Map))
+func KindOf(x interface{}, kind reflect.Kind) Comparison {
+	return func() Result {
+		if x == nil {
+			return ResultFailure(fmt.Sprintf("nil has no type, expected kind %s", kind))
+		}
+		actual := reflect.TypeOf(x).Kind()
+		return toResult(actual == kind,
+			fmt.Sprintf("%v (type %T) is kind %s, not %s", x, x, actual, kind))
+	}
+}
+
+// Assignable succeeds if a value of the type of x can be assigned to a variable
+// of the target type, using the rules of reflect.Type.AssignableTo. targetPtr
+// must be a pointer to a value of the target type.
+//
+// A nil x is assignable to any pointer, interface, map, slice, channel, or
+// function type.
+//
+// Example:
+//   assert.Assert(t, cmp.Assignable(value, (*io.Reader)(nil)))
+//   assert.Assert(t, cmp.Assignable(value, new(Config)))
+func Assignable(x interface{}, targetPtr interface{}) Comparison {
+	return func() Result {
+		ptrType := reflect.TypeOf(targetPtr)
+		if ptrType == nil || ptrType.Kind() != reflect.Ptr {
+			return ResultFailure(fmt.Sprintf(
+				"invalid type %T for target, expected a pointer to a value of the target type",
+				targetPtr))
+		}
+		target := ptrType.Elem()
+		if x == nil {
+			return toResult(isNillable(target),
+				fmt.Sprintf("nil is not assignable to type %s", target))
+		}
+		return toResult(reflect.TypeOf(x).AssignableTo(target),
+			fmt.Sprintf("type %T is not assignable to type %s", x, target))
+	}
+}
+
+// IsType succeeds if x is assignable to the target type, using the same rules
+// as Assignable. targetPtr must be a pointer to a value of the target type. If
+// targetPtr is not nil, and the comparison succeeds, x is stored in the value
+// that targetPtr points to, so that it can be used as the target type without a
+// type assertion. If the comparison fails the target is not changed.
+//
+// The value is stored when the comparison is run, not when IsType is called.
+//
+// Example:
+//   var cfg *Config
+//   assert.Assert(t, cmp.IsType(value, &cfg))
+//   assert.Equal(t, cfg.Name, "default")
+func IsType(x interface{}, targetPtr interface{}) Comparison {
+	return func() Result {
+		result := Assignable(x, targetPtr)()
+		if !result.Success() {
+			return result
+		}
+		target := reflect.ValueOf(targetPtr)
+		if target.IsNil() {
+			return result
+		}
+		if x == nil {
+			target.Elem().Set(reflect.Zero(target.Type().Elem()))
+		} else {
+			target.Elem().Set(reflect.ValueOf(x))
+		}
+		return result
+	}
+}
+
+func isNillable(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan,
+		reflect.Func:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,190 @@
+package cmp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestImplements(t *testing.T) {
+	var testcases = []struct {
+		name        string
+		x           interface{}
+		ifacePtr    interface{}
+		expectedMsg string
+	}{
+		{
+			name:     "implements",
+			x:        &bytes.Buffer{},
+			ifacePtr: (*io.Reader)(nil),
+		},
+		{
+			name:     "implements empty interface",
+			x:        3,
+			ifacePtr: (*interface{})(nil),
+		},
+		{
+			name:        "value does not implement",
+			x:           bytes.Buffer{},
+			ifacePtr:    (*io.Reader)(nil),
+			expectedMsg: "bytes.Buffer does not implement io.Reader",
+		},
+		{
+			name:        "nil value",
+			x:           nil,
+			ifacePtr:    (*fmt.Stringer)(nil),
+			expectedMsg: "nil does not implement fmt.Stringer",
+		},
+		{
+			name:     "nil interface",
+			x:        &bytes.Buffer{},
+			ifacePtr: nil,
+			expectedMsg: "invalid type <nil> for interface, expected a pointer to an " +
+				"interface, for example (*io.Reader)(nil)",
+		},
+		{
+			name:     "not a pointer to an interface",
+			x:        &bytes.Buffer{},
+			ifacePtr: &bytes.Buffer{},
+			expectedMsg: "invalid type *bytes.Buffer for interface, expected a pointer to an " +
+				"interface, for example (*io.Reader)(nil)",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result := Implements(testcase.x, testcase.ifacePtr)()
+			if testcase.expectedMsg == "" {
+				assertSuccess(t, result)
+			} else {
+				assertFailure(t, result, testcase.expectedMsg)
+			}
+		})
+	}
+}
+
+func TestSameType(t *testing.T) {
+	assertSuccess(t, SameType(1, 2)())
+	assertSuccess(t, SameType(&bytes.Buffer{}, new(bytes.Buffer))())
+
+	assertFailure(t, SameType(1, int64(1))(), "type int is not the same as type int64")
+	assertFailure(t, SameType(bytes.Buffer{}, &bytes.Buffer{})(),
+		"type bytes.Buffer is not the same as type *bytes.Buffer")
+	assertFailure(t, SameType(nil, 1)(), "nil has no type, got <nil> and int")
+	assertFailure(t, SameType(1, nil)(), "nil has no type, got int and <nil>")
+}
+
+func TestKindOf(t *testing.T) {
+	assertSuccess(t, KindOf(map[string]interface{}{}, reflect.Map)())
+	assertSuccess(t, KindOf([]interface{}{}, reflect.Slice)())
+
+	assertFailure(t, KindOf([]interface{}{"a"}, reflect.Map)(),
+		"[a] (type []interface {}) is kind slice, not map")
+	assertFailure(t, KindOf(nil, reflect.Ptr)(), "nil has no type, expected kind ptr")
+}
+
+type namedSlice []string
+
+func TestAssignable(t *testing.T) {
+	var testcases = []struct {
+		name        string
+		x           interface{}
+		targetPtr   interface{}
+		expectedMsg string
+	}{
+		{name: "same type", x: 3, targetPtr: new(int)},
+		{name: "to interface", x: &bytes.Buffer{}, targetPtr: (*io.Writer)(nil)},
+		{name: "named to unnamed", x: namedSlice{"a"}, targetPtr: new([]string)},
+		{name: "nil to interface", x: nil, targetPtr: (*error)(nil)},
+		{name: "nil to slice", x: nil, targetPtr: new([]int)},
+		{
+			name:        "different types",
+			x:           int32(3),
+			targetPtr:   new(int),
+			expectedMsg: "type int32 is not assignable to type int",
+		},
+		{
+			name:        "does not implement interface",
+			x:           bytes.Buffer{},
+			targetPtr:   (*io.Writer)(nil),
+			expectedMsg: "type bytes.Buffer is not assignable to type io.Writer",
+		},
+		{
+			name:        "nil to int",
+			x:           nil,
+			targetPtr:   new(int),
+			expectedMsg: "nil is not assignable to type int",
+		},
+		{
+			name:      "nil target",
+			x:         3,
+			targetPtr: nil,
+			expectedMsg: "invalid type <nil> for target, " +
+				"expected a pointer to a value of the target type",
+		},
+		{
+			name:      "target is not a pointer",
+			x:         3,
+			targetPtr: 3,
+			expectedMsg: "invalid type int for target, " +
+				"expected a pointer to a value of the target type",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result := Assignable(testcase.x, testcase.targetPtr)()
+			if testcase.expectedMsg == "" {
+				assertSuccess(t, result)
+			} else {
+				assertFailure(t, result, testcase.expectedMsg)
+			}
+		})
+	}
+}
+
+func TestIsType(t *testing.T) {
+	t.Run("stores the value", func(t *testing.T) {
+		var value interface{} = &bytes.Buffer{}
+		var buf *bytes.Buffer
+		assertSuccess(t, IsType(value, &buf)())
+		if buf != value {
+			t.Errorf("expected target to be %p, got %p", value, buf)
+		}
+	})
+
+	t.Run("stores the value as an interface", func(t *testing.T) {
+		var writer io.Writer
+		assertSuccess(t, IsType(&bytes.Buffer{}, &writer)())
+		if _, ok := writer.(*bytes.Buffer); !ok {
+			t.Errorf("expected target to be a *bytes.Buffer, got %T", writer)
+		}
+	})
+
+	t.Run("stores nil", func(t *testing.T) {
+		values := []int{1}
+		assertSuccess(t, IsType(nil, &values)())
+		if values != nil {
+			t.Errorf("expected target to be nil, got %v", values)
+		}
+	})
+
+	t.Run("nil target pointer", func(t *testing.T) {
+		assertSuccess(t, IsType(3, (*int)(nil))())
+	})
+
+	t.Run("different types leaves target unchanged", func(t *testing.T) {
+		target := 7
+		result := IsType(int32(3), &target)()
+		assertFailure(t, result, "type int32 is not assignable to type int")
+		if target != 7 {
+			t.Errorf("expected target to be unchanged, got %v", target)
+		}
+	})
+
+	t.Run("target is not a pointer", func(t *testing.T) {
+		result := IsType(3, 3)()
+		assertFailure(t, result, "invalid type int for target, "+
+			"expected a pointer to a value of the target type")
+	})
+}
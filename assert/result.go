@@ -19,7 +19,8 @@ func runComparison(
 		ht.Helper()
 	}
 	result := f()
-	if result.Success() {
+	warning := cmp.ResultSeverity(result) == cmp.SeverityWarning
+	if result.Success() && !warning {
 		return true
 	}
 
@@ -38,6 +39,10 @@ func runComparison(
 		message = fmt.Sprintf("comparison returned invalid Result type: %T", result)
 	}
 
+	if warning {
+		t.Log(format.WithCustomMessage(warningMessage+message, msgAndArgs...))
+		return true
+	}
 	t.Log(format.WithCustomMessage(failureMessage+message, msgAndArgs...))
 	return false
 }
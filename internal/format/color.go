@@ -0,0 +1,71 @@
+package format
+
+import (
+	"os"
+	"strings"
+)
+
+// ColorEnvVar is the name of the environment variable used to enable colors in
+// diffs. A value of "1", "true", or "auto" enables colors when stdout is a
+// terminal. A value of "always" enables colors even when stdout is not a
+// terminal. A value of "0", "false", or "never" disables colors.
+const ColorEnvVar = "GOTESTTOOLS_COLOR"
+
+const (
+	colorRed   = "\x1b[31m"
+	colorGreen = "\x1b[32m"
+	colorReset = "\x1b[0m"
+)
+
+// isTerminal is replaced by tests.
+var isTerminal = func(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// ColorEnabled returns true if diffs should be colored. enabled is the default
+// used when ColorEnvVar is not set. Colors are only enabled if stdout is a
+// terminal, unless ColorEnvVar is set to "always".
+func ColorEnabled(enabled bool) bool {
+	switch strings.ToLower(os.Getenv(ColorEnvVar)) {
+	case "always":
+		return true
+	case "1", "true", "auto":
+		enabled = true
+	case "0", "false", "never":
+		return false
+	}
+	return enabled && isTerminal(os.Stdout)
+}
+
+// ColorDiff returns diff with ANSI colors added to each line. Removed lines,
+// which start with "-", are red, and added lines, which start with "+", are
+// green. The "---" and "+++" header lines at the start of a unified diff are
+// not colored.
+func ColorDiff(diff string) string {
+	lines := strings.SplitAfter(diff, "\n")
+	header := true
+	for i, line := range lines {
+		if header && (strings.HasPrefix(line, "--- ") || strings.HasPrefix(line, "+++ ")) {
+			continue
+		}
+		header = false
+		switch {
+		case strings.HasPrefix(line, "-"):
+			lines[i] = colorLine(colorRed, line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = colorLine(colorGreen, line)
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// colorLine wraps line in color, keeping any newline outside of the escape
+// sequences so that the diff is still split into the same lines.
+func colorLine(color string, line string) string {
+	text := strings.TrimSuffix(line, "\n")
+	return color + text + colorReset + line[len(text):]
+}
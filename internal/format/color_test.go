@@ -0,0 +1,50 @@
+package format
+
+import (
+	"os"
+	"testing"
+)
+
+func TestColorDiff(t *testing.T) {
+	diff := "--- from\n+++ to\n@@ -1,3 +1,3 @@\n a\n-b\n+c\n-- d\n+e"
+	expected := "--- from\n+++ to\n@@ -1,3 +1,3 @@\n a\n" +
+		"\x1b[31m-b\x1b[0m\n\x1b[32m+c\x1b[0m\n\x1b[31m-- d\x1b[0m\n\x1b[32m+e\x1b[0m"
+	if actual := ColorDiff(diff); actual != expected {
+		t.Errorf("expected\n%q\ngot\n%q", expected, actual)
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	defer func(orig func(*os.File) bool) { isTerminal = orig }(isTerminal)
+	defer func(orig string, exists bool) {
+		if !exists {
+			os.Unsetenv(ColorEnvVar)
+			return
+		}
+		os.Setenv(ColorEnvVar, orig)
+	}(os.LookupEnv(ColorEnvVar))
+
+	var testcases = []struct {
+		env      string
+		enabled  bool
+		terminal bool
+		expected bool
+	}{
+		{env: "", enabled: false, terminal: true, expected: false},
+		{env: "", enabled: true, terminal: true, expected: true},
+		{env: "", enabled: true, terminal: false, expected: false},
+		{env: "1", enabled: false, terminal: true, expected: true},
+		{env: "auto", enabled: false, terminal: false, expected: false},
+		{env: "always", enabled: false, terminal: false, expected: true},
+		{env: "never", enabled: true, terminal: true, expected: false},
+	}
+	for _, testcase := range testcases {
+		os.Setenv(ColorEnvVar, testcase.env)
+		terminal := testcase.terminal
+		isTerminal = func(*os.File) bool { return terminal }
+		if actual := ColorEnabled(testcase.enabled); actual != testcase.expected {
+			t.Errorf("env=%q enabled=%v terminal=%v: expected %v, got %v",
+				testcase.env, testcase.enabled, testcase.terminal, testcase.expected, actual)
+		}
+	}
+}
@@ -19,6 +19,10 @@ type DiffConfig struct {
 	B    string
 	From string
 	To   string
+	// Context is the number of unchanged lines to include around each change.
+	// If Context is 0 the default of 2 lines is used. A negative value
+	// includes no unchanged lines.
+	Context int
 }
 
 // UnifiedDiff is a modified version of difflib.WriteUnifiedDiff with better
@@ -26,7 +30,7 @@ type DiffConfig struct {
 func UnifiedDiff(conf DiffConfig) string {
 	a := strings.SplitAfter(conf.A, "\n")
 	b := strings.SplitAfter(conf.B, "\n")
-	groups := difflib.NewMatcher(a, b).GetGroupedOpCodes(contextLines)
+	groups := difflib.NewMatcher(a, b).GetGroupedOpCodes(conf.contextLines())
 	if len(groups) == 0 {
 		return ""
 	}
@@ -62,6 +66,16 @@ func UnifiedDiff(conf DiffConfig) string {
 	return buf.String()
 }
 
+func (conf DiffConfig) contextLines() int {
+	switch {
+	case conf.Context == 0:
+		return contextLines
+	case conf.Context < 0:
+		return 0
+	}
+	return conf.Context
+}
+
 // hasWhitespaceDiffLines returns true if any diff groups is only different
 // because of whitespace characters.
 func hasWhitespaceDiffLines(groups [][]difflib.OpCode, a, b []string) bool {
@@ -154,8 +168,9 @@ func formatLines(writeLine func(string, string), prefix string, lines []string)
 		writeLine(prefix, line)
 	}
 	// Add a newline if the last line is missing one so that the diff displays
-	// properly.
-	if !strings.HasSuffix(lines[len(lines)-1], "\n") {
+	// properly. lines is empty for an unchanged group when there are no
+	// context lines.
+	if len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
 		writeLine("", "\n")
 	}
 }
@@ -69,3 +69,19 @@ func TestUnifiedDiff(t *testing.T) {
 		})
 	}
 }
+
+func TestUnifiedDiffWithContext(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n"
+	b := "1\n2\n3\nfour\n5\n6\n7\n"
+
+	t.Run("more context", func(t *testing.T) {
+		diff := format.UnifiedDiff(format.DiffConfig{A: a, B: b, Context: 3})
+		expected := "@@ -1,7 +1,7 @@\n 1\n 2\n 3\n-4\n+four\n 5\n 6\n 7\n"
+		assert.Equal(t, diff, expected)
+	})
+
+	t.Run("no context", func(t *testing.T) {
+		diff := format.UnifiedDiff(format.DiffConfig{A: a, B: b, Context: -1})
+		assert.Equal(t, diff, "@@ -4 +4 @@\n-4\n+four\n")
+	})
+}
@@ -0,0 +1,30 @@
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+type multiWrapper interface {
+	Unwrap() []error
+}
+
+// MultiError formats an error which wraps multiple errors, such as an error
+// created by errors.Join, as a list with each wrapped error on a separate line.
+// Each error is formatted using %+v. MultiError returns false if err does not
+// have an Unwrap() []error method.
+func MultiError(err error) (string, bool) {
+	wrapper, ok := err.(multiWrapper)
+	if !ok {
+		return "", false
+	}
+	errs := wrapper.Unwrap()
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "%d errors:", len(errs))
+	for _, wrapped := range errs {
+		msg := strings.Replace(fmt.Sprintf("%+v", wrapped), "\n", "\n    ", -1)
+		fmt.Fprintf(buf, "\n  - %s", msg)
+	}
+	return buf.String(), true
+}
@@ -0,0 +1,29 @@
+package format_test
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/internal/format"
+)
+
+type multiError []error
+
+func (e multiError) Error() string {
+	return "multiple errors"
+}
+
+func (e multiError) Unwrap() []error {
+	return e
+}
+
+func TestMultiError(t *testing.T) {
+	err := multiError{errors.New("first"), errors.New("second\nline")}
+	msg, ok := format.MultiError(err)
+	assert.Assert(t, ok)
+	assert.Equal(t, msg, "2 errors:\n  - first\n  - second\n    line")
+
+	_, ok = format.MultiError(errors.New("single"))
+	assert.Assert(t, !ok)
+}